@@ -0,0 +1,82 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/boogie-byte/oli/internal/config"
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+// runCommand runs the command given by the command line arguments
+// instead of the outline UI.
+func runCommand(directory, document string, cfg *config.Config, args []string) error {
+	switch args[0] {
+	case "add":
+		return runAdd(directory, document, cfg, args[1:])
+	case "export":
+		return runExport(directory, document, cfg, args[1:], os.Stdout)
+	default:
+		return fmt.Errorf("unknown command %q, expected one of: add, export", args[0])
+	}
+}
+
+// runAdd appends an item with the title made of the arguments to the
+// inbox item and saves the document.
+func runAdd(directory, document string, cfg *config.Config, args []string) error {
+	title := strings.TrimSpace(strings.Join(args, " "))
+	if title == "" {
+		return errors.New("nothing to add, the item title is missing")
+	}
+
+	w, err := loadWorkspace(directory, document, cfg)
+	if err != nil {
+		return err
+	}
+
+	w.Inbox(cfg.Inbox).Append(w.NewItem(title))
+
+	return w.Save()
+}
+
+// runExport writes the whole document tree to stdout.
+func runExport(directory, document string, cfg *config.Config, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := fs.String("format", "markdown", "export format, one of: "+strings.Join(data.ExportFormats(), ", "))
+	if err := fs.Parse(args); errors.Is(err, flag.ErrHelp) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	w, err := loadWorkspace(directory, document, cfg)
+	if err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(stdout)
+	if err := data.Export(*format, w.Root().RealRoot(), out); err != nil {
+		return err
+	}
+
+	return out.Flush()
+}
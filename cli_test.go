@@ -0,0 +1,53 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/config"
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestRunExport(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.Default()
+	require.NoError(t, runAdd(dir, data.DefaultDocument, cfg, []string{"Buy", "milk"}))
+
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, runExport(dir, data.DefaultDocument, cfg, []string{"--format", "json"}, &buf))
+
+		w, err := data.ImportJSON(&buf)
+		require.NoError(t, err)
+
+		var titles []string
+		w.Root().RealRoot().Walk(func(c *data.Item) error {
+			titles = append(titles, c.Title())
+			return nil
+		})
+		assert.Contains(t, titles, "Buy milk")
+	})
+
+	t.Run("UnknownFormat", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := runExport(dir, data.DefaultDocument, cfg, []string{"--format", "docx"}, &buf)
+		assert.ErrorContains(t, err, "unknown export format")
+	})
+}
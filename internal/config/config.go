@@ -0,0 +1,154 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+const configFilename = "config.yaml"
+
+type Config struct {
+	// Keep a timestamped backup of the workspace file on every save
+	Backups bool `yaml:"backups"`
+
+	// Number of the most recent backups kept, all of them if 0
+	BackupLimit int `yaml:"backup_limit"`
+
+	// Gzip the workspace file
+	Compress bool `yaml:"compress"`
+
+	// Encrypt the workspace file with the passphrase taken from the
+	// OLI_PASSPHRASE environment variable or asked for on start
+	Encrypt bool `yaml:"encrypt"`
+
+	// Number of days ahead shown by the "due soon" view
+	DueDays int `yaml:"due_days"`
+
+	// Expand collapsed items when the cursor moves onto them
+	AutoExpand bool `yaml:"auto_expand"`
+
+	// Wrap the long titles instead of truncating them
+	WrapTitles bool `yaml:"wrap_titles"`
+
+	// Show a progress bar after the to-do statistics of the items
+	ProgressBars bool `yaml:"progress_bars"`
+
+	// Show the date the done items were completed on
+	ShowCompleted bool `yaml:"show_completed"`
+
+	// Draw the guide lines connecting the items to their parents in
+	// the indentation
+	IndentGuides bool `yaml:"indent_guides"`
+
+	// Count all the descendants in the to-do statistics of the items
+	// rather than the children
+	DeepToDoStats bool `yaml:"deep_todo_stats"`
+
+	// Continue the jumps between the items with a status from the other
+	// end of the list
+	WrapStatusJumps bool `yaml:"wrap_status_jumps"`
+
+	// Title of the top-level item the captured items are added to, the
+	// real root if empty
+	Inbox string `yaml:"inbox"`
+
+	// Name of the color theme
+	Theme string `yaml:"theme"`
+
+	// Go time layout of the timestamps inserted into titles
+	TimestampFormat string `yaml:"timestamp_format"`
+
+	// Title of the top-level item the daily notes are added to, the
+	// real root if empty
+	Journal string `yaml:"journal"`
+
+	// Go time layout of the daily note titles
+	JournalFormat string `yaml:"journal_format"`
+
+	// Mark the items without a status done too when completing an item
+	// with its ancestors
+	CompleteUnstatused bool `yaml:"complete_unstatused"`
+
+	// Mark an item with a status done when all its children with
+	// a status are completed, and reopen it when one of them is reopened
+	AutoCompleteParents bool `yaml:"auto_complete_parents"`
+
+	// Status names of the imported files mapped to the status keywords
+	StatusAliases map[string]string `yaml:"status_aliases"`
+
+	// Action names mapped to the keys replacing their default bindings
+	Keys map[string]KeyList `yaml:"keys"`
+}
+
+// KeyList is a list of keys bound to an action. In the config file, it
+// is either a single key or a sequence of them.
+type KeyList []string
+
+func (l *KeyList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*l = KeyList{value.Value}
+		return nil
+	}
+
+	var keys []string
+	if err := value.Decode(&keys); err != nil {
+		return err
+	}
+
+	*l = keys
+	return nil
+}
+
+// Default returns the configuration used when no config file exists.
+func Default() *Config {
+	return &Config{
+		Backups:     true,
+		BackupLimit: data.DefaultBackupLimit,
+		DueDays:     7,
+		Inbox:       "Scratch",
+		Theme:       "default",
+
+		TimestampFormat: time.DateOnly,
+		Journal:         "Journal",
+		JournalFormat:   time.DateOnly,
+	}
+}
+
+// Load reads the config file from the directory. Settings missing from
+// the file keep their default values.
+func Load(directory string) (*Config, error) {
+	c := Default()
+
+	data, err := os.ReadFile(filepath.Join(directory, configFilename))
+	if os.IsNotExist(err) {
+		return c, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/config"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("MissingFile", func(t *testing.T) {
+		c, err := config.Load(t.TempDir())
+		require.NoError(t, err)
+		assert.Equal(t, config.Default(), c)
+	})
+
+	t.Run("BackupsOff", func(t *testing.T) {
+		dir := writeConfig(t, "backups: off\n")
+
+		c, err := config.Load(dir)
+		require.NoError(t, err)
+		assert.False(t, c.Backups)
+	})
+
+	t.Run("Keys", func(t *testing.T) {
+		dir := writeConfig(t, "keys:\n  cursorUp: alt+k\n  cursorDown: [alt+j, ctrl+down]\n")
+
+		c, err := config.Load(dir)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]config.KeyList{
+			"cursorUp":   {"alt+k"},
+			"cursorDown": {"alt+j", "ctrl+down"},
+		}, c.Keys)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		dir := writeConfig(t, "backups: [\n")
+
+		_, err := config.Load(dir)
+		assert.Error(t, err)
+	})
+}
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(content), 0600))
+
+	return dir
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+// ArchiveTitle is the title of the top-level item the completed items
+// are archived under.
+const ArchiveTitle = "Archive"
+
+// ArchiveCompleted moves the done and canceled children of the item,
+// with their subtrees, to the end of the top-level "Archive" item,
+// which is created if missing. If recursive is true, the completed
+// items deeper in the subtrees are moved too. The archive itself is
+// left alone, and nothing is archived from inside of it. It returns
+// the archive and the number of the moved items, or nil and zero if
+// there is nothing to archive.
+func (i *Item) ArchiveCompleted(recursive bool) (*Item, int) {
+	root := i.RealRoot()
+
+	var archive *Item
+	for c := root.head; c != nil; c = c.next {
+		if c.title == ArchiveTitle {
+			archive = c
+			break
+		}
+	}
+
+	if archive != nil && (i == archive || i.IsDescendantOf(archive)) {
+		return nil, 0
+	}
+
+	var completed []*Item
+
+	var walk func(parent *Item)
+	walk = func(parent *Item) {
+		for c := parent.head; c != nil; c = c.next {
+			switch {
+			case c == archive:
+				continue
+			case c.status == StatusDone || c.status == StatusCanceled:
+				completed = append(completed, c)
+			case recursive:
+				walk(c)
+			}
+		}
+	}
+	walk(i)
+
+	if len(completed) == 0 {
+		return nil, 0
+	}
+
+	defer i.workspace.batch()()
+
+	if archive == nil {
+		archive = i.workspace.NewItem(ArchiveTitle)
+		root.Append(archive)
+	}
+
+	for _, c := range completed {
+		archive.Append(c)
+	}
+
+	return archive, len(completed)
+}
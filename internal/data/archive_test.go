@@ -0,0 +1,104 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestItemArchiveCompleted(t *testing.T) {
+	t.Run("CreatesArchive", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+		nested := w.NewItem("Nested")
+		root.Append(a)
+		root.Append(b)
+		root.Append(c)
+		b.Append(nested)
+		b.SetStatus(data.StatusDone)
+		c.SetStatus(data.StatusCanceled)
+
+		archive, n := root.ArchiveCompleted(false)
+		require.NotNil(t, archive)
+		assert.Equal(t, 2, n)
+		assert.Equal(t, data.ArchiveTitle, archive.Title())
+
+		assertChildrenOrder(t, root, a, archive)
+		assertChildrenOrder(t, archive, b, c)
+
+		// the subtrees and the statuses move along
+		assertChildrenOrder(t, b, nested)
+		assert.Equal(t, data.StatusDone, b.Status())
+		assert.Equal(t, data.StatusCanceled, c.Status())
+
+		// a single undo step restores the tree
+		w.Undo()
+		assertChildrenOrder(t, root, a, b, c)
+		assert.Nil(t, archive.Parent())
+	})
+
+	t.Run("ExistingArchive", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+		old := w.NewItem("Old")
+		archive := w.NewItem(data.ArchiveTitle)
+		root.Append(archive)
+		archive.Append(old)
+		root.Append(a)
+		a.Append(b)
+		a.Append(c)
+		old.SetStatus(data.StatusDone)
+		c.SetStatus(data.StatusDone)
+
+		// archiving from a nested level goes to the top-level archive
+		got, n := a.ArchiveCompleted(false)
+		assert.Same(t, archive, got)
+		assert.Equal(t, 1, n)
+
+		assertChildrenOrder(t, root, archive, a)
+		assertChildrenOrder(t, archive, old, c)
+		assertChildrenOrder(t, a, b)
+	})
+
+	t.Run("Recursive", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+		root.Append(a)
+		a.Append(b)
+		b.Append(c)
+		c.SetStatus(data.StatusDone)
+
+		_, n := root.ArchiveCompleted(false)
+		assert.Zero(t, n)
+		assertChildrenOrder(t, root, a)
+
+		archive, n := root.ArchiveCompleted(true)
+		assert.Equal(t, 1, n)
+		assertChildrenOrder(t, root, a, archive)
+		assertChildrenOrder(t, archive, c)
+		assert.Nil(t, b.Head())
+
+		// the archived items stay in the archive
+		_, n = root.ArchiveCompleted(true)
+		assert.Zero(t, n)
+		_, n = archive.ArchiveCompleted(true)
+		assert.Zero(t, n)
+	})
+}
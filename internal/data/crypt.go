@@ -0,0 +1,173 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+	"os"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// encryptedMagic starts the encrypted workspace files. It is followed
+// by the key derivation salt, the nonce and the sealed XML.
+const encryptedMagic = "oli-encrypted-v1\n"
+
+const (
+	saltSize = 16
+	keySize  = 32
+
+	// scrypt cost parameters
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+var (
+	// ErrEncrypted is returned when loading an encrypted workspace
+	// file without a passphrase.
+	ErrEncrypted = errors.New("workspace file is encrypted, a passphrase is required")
+
+	// ErrWrongPassphrase is returned when an encrypted workspace file
+	// can not be decrypted with the given passphrase.
+	ErrWrongPassphrase = errors.New("wrong passphrase or corrupt workspace file")
+)
+
+// PassphraseFunc returns the passphrase of an encrypted workspace file.
+// It is called once, when such a file is found.
+type PassphraseFunc func() (string, error)
+
+// isEncrypted reports whether the data starts with the encrypted file
+// header.
+func isEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(encryptedMagic))
+}
+
+// isEncryptedFile reports whether the file starts with the encrypted
+// file header.
+func isEncryptedFile(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	header := make([]byte, len(encryptedMagic))
+	if _, err := io.ReadFull(f, header); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return isEncrypted(header), nil
+}
+
+// removePlainBackups removes the backups of the file which are not
+// encrypted, so the encrypted workspace is not left readable on disk
+// in them.
+func removePlainBackups(directory, filename string) error {
+	backups, err := listBackups(directory, filename)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range backups {
+		encrypted, err := isEncryptedFile(b.path)
+		if err != nil {
+			return err
+		}
+		if encrypted {
+			continue
+		}
+		if err := os.Remove(b.path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// encrypt seals the data with AES-GCM under a key derived from the
+// passphrase with scrypt. The header is authenticated along with the
+// data.
+func encrypt(data []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	aead, err := newCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	header := append([]byte(encryptedMagic), salt...)
+	header = append(header, nonce...)
+
+	return aead.Seal(header, nonce, data, header), nil
+}
+
+// decrypt opens the data sealed by encrypt.
+func decrypt(data []byte, passphrase string) ([]byte, error) {
+	body := data[len(encryptedMagic):]
+	if len(body) < saltSize {
+		return nil, ErrWrongPassphrase
+	}
+
+	aead, err := newCipher(passphrase, body[:saltSize])
+	if err != nil {
+		return nil, err
+	}
+
+	headerSize := len(encryptedMagic) + saltSize + aead.NonceSize()
+	if len(data) < headerSize {
+		return nil, ErrWrongPassphrase
+	}
+
+	header := data[:headerSize]
+	nonce := header[len(encryptedMagic)+saltSize:]
+
+	plain, err := aead.Open(nil, nonce, data[headerSize:], header)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	return plain, nil
+}
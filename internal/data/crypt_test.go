@@ -0,0 +1,166 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func passphrase(p string) data.PassphraseFunc {
+	return func() (string, error) {
+		return p, nil
+	}
+}
+
+func TestWorkspaceEncryptionBackups(t *testing.T) {
+	w, _ := newSavedWorkspace(t)
+	dir := w.Directory()
+
+	old := filepath.Join(dir, "workspace.xml.bak.1000")
+	require.NoError(t, os.WriteFile(old, []byte("<oli-workspace/>"), 0600))
+	require.NotEmpty(t, listBackups(t, dir))
+
+	assertEncrypted := func(t *testing.T) {
+		t.Helper()
+
+		for _, b := range listBackups(t, dir) {
+			raw, err := os.ReadFile(b)
+			require.NoError(t, err)
+			assert.NotContains(t, string(raw), "<oli-workspace", b)
+		}
+	}
+
+	// the plain file is not kept as a backup, and the plain backups
+	// are removed
+	w.SetPassphrase("secret")
+	require.NoError(t, w.Save())
+	assert.Empty(t, listBackups(t, dir))
+
+	// the encrypted files are backed up as usual
+	require.NoError(t, w.Save())
+	assert.Len(t, listBackups(t, dir), 1)
+	assertEncrypted(t)
+
+	// a plain backup appearing later is removed on the next save
+	require.NoError(t, os.WriteFile(old, []byte("<oli-workspace/>"), 0600))
+	require.NoError(t, w.Save())
+	assert.NoFileExists(t, old)
+	assertEncrypted(t)
+}
+
+func TestWorkspaceEncryption(t *testing.T) {
+	w, items := newSavedWorkspace(t)
+	w.SetPassphrase("secret")
+	require.NoError(t, w.Save())
+
+	raw, err := os.ReadFile(filepath.Join(w.Directory(), "workspace.xml"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "<oli-workspace")
+	assert.NotContains(t, string(raw), "<title>B</title>")
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		loaded, err := data.LoadWorkspace(w.Directory(), passphrase("secret"))
+		require.NoError(t, err)
+		assert.True(t, loaded.Encrypted())
+
+		var titles []string
+		for c := loaded.Root().Head(); c != nil; c = c.Next() {
+			titles = append(titles, c.Title())
+		}
+		assert.Equal(t, []string{"A", "B", "C"}, titles)
+		assert.Same(t, loaded.Root().Head(), loaded.Cursor())
+		assert.Equal(t, items[0].ID(), loaded.Cursor().ID())
+
+		// the file stays encrypted on the next save
+		require.NoError(t, loaded.Save())
+		_, err = data.LoadWorkspace(w.Directory(), passphrase("secret"))
+		require.NoError(t, err)
+	})
+
+	t.Run("WrongPassphrase", func(t *testing.T) {
+		_, err := data.LoadWorkspace(w.Directory(), passphrase("guess"))
+		assert.ErrorIs(t, err, data.ErrWrongPassphrase)
+	})
+
+	t.Run("NoPassphrase", func(t *testing.T) {
+		_, err := data.LoadWorkspace(w.Directory(), nil)
+		assert.ErrorIs(t, err, data.ErrEncrypted)
+	})
+
+	t.Run("Decrypt", func(t *testing.T) {
+		loaded, err := data.LoadWorkspace(w.Directory(), passphrase("secret"))
+		require.NoError(t, err)
+
+		loaded.SetPassphrase("")
+		require.NoError(t, loaded.Save())
+
+		// the plain file is loaded without asking for the passphrase
+		called := false
+		loaded, err = data.LoadWorkspace(w.Directory(), func() (string, error) {
+			called = true
+			return "", nil
+		})
+		require.NoError(t, err)
+		assert.False(t, loaded.Encrypted())
+		assert.False(t, called)
+	})
+}
+
+func TestWorkspaceOpenEncrypted(t *testing.T) {
+	w, _ := newSavedWorkspace(t)
+	dir := w.Directory()
+	w.SetPassphrase("secret")
+
+	assertEncrypted := func(t *testing.T, name string) {
+		t.Helper()
+
+		raw, err := os.ReadFile(filepath.Join(dir, name+".xml"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(raw), "<oli-workspace")
+	}
+
+	t.Run("Created", func(t *testing.T) {
+		// the first save of the created file is encrypted already
+		work, err := w.Open("work")
+		require.NoError(t, err)
+		assert.True(t, work.Encrypted())
+		assertEncrypted(t, "work")
+	})
+
+	t.Run("Plain", func(t *testing.T) {
+		_, err := data.LoadDocument(dir, "plain", nil)
+		require.NoError(t, err)
+
+		plain, err := w.Open("plain")
+		require.NoError(t, err)
+		assert.True(t, plain.Encrypted())
+
+		require.NoError(t, plain.Save())
+		assertEncrypted(t, "plain")
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		reloaded, err := w.Reload()
+		require.NoError(t, err)
+		assert.True(t, reloaded.Encrypted())
+	})
+}
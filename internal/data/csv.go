@@ -0,0 +1,161 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	csvColumnTitle  = "title"
+	csvColumnStatus = "status"
+	csvColumnDue    = "due"
+)
+
+var errMissingTitleColumn = errors.New("CSV header has no title column")
+
+// SkippedRowsError reports the number of malformed rows skipped by
+// the import. The rest of the rows are imported.
+type SkippedRowsError struct {
+	Rows int
+}
+
+func (e *SkippedRowsError) Error() string {
+	return fmt.Sprintf("skipped %d malformed rows", e.Rows)
+}
+
+// SetStatusAliases sets the mapping from the status names used by the
+// imported files to the status keywords, e.g. "in progress" to "TODO".
+// The names are matched ignoring case.
+func (w *Workspace) SetStatusAliases(aliases map[string]string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.statusAliases = make(map[string]Status, len(aliases))
+	for name, keyword := range aliases {
+		s, err := ParseStatus(strings.ToUpper(keyword))
+		if err != nil {
+			return err
+		}
+		w.statusAliases[strings.ToLower(strings.TrimSpace(name))] = s
+	}
+
+	return nil
+}
+
+// importStatus resolves a status name from an imported file, trying the
+// aliases first and the status keywords next. An empty name means none.
+func (w *Workspace) importStatus(name string) (Status, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return StatusNone, nil
+	}
+
+	if s, ok := w.statusAliases[strings.ToLower(name)]; ok {
+		return s, nil
+	}
+
+	return ParseStatus(strings.ToUpper(name))
+}
+
+// ImportCSV appends an item under the parent for every data row of the
+// CSV. The header row names the title, status and due columns in any
+// order; other columns are ignored and only the title one is required.
+// The rows with an unknown status, a bad due date or broken quoting are
+// skipped and reported with a *SkippedRowsError.
+func (w *Workspace) ImportCSV(r io.Reader, parent *Item) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return errMissingTitleColumn
+	} else if err != nil {
+		return err
+	}
+
+	columns := make(map[string]int)
+	for idx, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := columns[name]; !ok {
+			columns[name] = idx
+		}
+	}
+
+	if _, ok := columns[csvColumnTitle]; !ok {
+		return errMissingTitleColumn
+	}
+
+	field := func(record []string, column string) string {
+		idx, ok := columns[column]
+		if !ok || idx >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[idx])
+	}
+
+	defer w.batch()()
+
+	skipped := 0
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			skipped++
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		status, err := w.importStatus(field(record, csvColumnStatus))
+		if err != nil {
+			skipped++
+			continue
+		}
+
+		var due time.Time
+		if s := field(record, csvColumnDue); s != "" {
+			if due, err = parseDue(s); err != nil {
+				skipped++
+				continue
+			}
+		}
+
+		// the setters stamp the completion time and record the changes
+		// in the import batch
+		item := w.NewItem(field(record, csvColumnTitle))
+		parent.Append(item)
+		item.SetStatus(status)
+		if !due.IsZero() {
+			item.SetDue(due)
+		}
+	}
+
+	if skipped > 0 {
+		return &SkippedRowsError{Rows: skipped}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestWorkspaceImportCSV(t *testing.T) {
+	t.Run("WellFormed", func(t *testing.T) {
+		w := data.NewWorkspace("", "Root")
+		require.NoError(t, w.SetStatusAliases(map[string]string{"In Progress": "todo"}))
+
+		in := "Owner,Due,Title,Status\n" +
+			"ann,2025-06-14,Write docs,done\n" +
+			"bob,,\"Fix bug, again\",in progress\n" +
+			"eve,,Plan\n"
+		require.NoError(t, w.ImportCSV(strings.NewReader(in), w.Root()))
+
+		a := w.Root().Head()
+		require.NotNil(t, a)
+		b := a.Next()
+		require.NotNil(t, b)
+		c := b.Next()
+		require.NotNil(t, c)
+		assertChildrenOrder(t, w.Root(), a, b, c)
+
+		assert.Equal(t, "Write docs", a.Title())
+		assert.Equal(t, data.StatusDone, a.Status())
+		assert.Equal(t, time.Date(2025, 6, 14, 0, 0, 0, 0, time.Local), a.Due())
+
+		assert.Equal(t, "Fix bug, again", b.Title())
+		assert.Equal(t, data.StatusToDo, b.Status())
+		assert.True(t, b.Due().IsZero())
+
+		assert.Equal(t, "Plan", c.Title())
+		assert.Equal(t, data.StatusNone, c.Status())
+
+		// the done items are stamped with the completion time
+		assert.False(t, a.Completed().IsZero())
+		assert.True(t, b.Completed().IsZero())
+
+		// the import is undone at once
+		w.Undo()
+		assertChildrenListEmpty(t, w.Root())
+	})
+
+	t.Run("BadDate", func(t *testing.T) {
+		w := data.NewWorkspace("", "Root")
+
+		in := "title,due\n" +
+			"First,2025-06-14\n" +
+			"Second,next week\n" +
+			"Third,\n"
+		err := w.ImportCSV(strings.NewReader(in), w.Root())
+
+		var skipped *data.SkippedRowsError
+		require.ErrorAs(t, err, &skipped)
+		assert.Equal(t, 1, skipped.Rows)
+
+		first := w.Root().Head()
+		require.NotNil(t, first)
+		assert.Equal(t, "First", first.Title())
+		require.NotNil(t, first.Next())
+		assert.Equal(t, "Third", first.Next().Title())
+		assert.Same(t, first.Next(), w.Root().Tail())
+	})
+
+	t.Run("NoTitleColumn", func(t *testing.T) {
+		w := data.NewWorkspace("", "Root")
+
+		err := w.ImportCSV(strings.NewReader("name,due\nFirst,\n"), w.Root())
+		assert.Error(t, err)
+		assertChildrenListEmpty(t, w.Root())
+	})
+}
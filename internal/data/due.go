@@ -0,0 +1,158 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DueLayout is the ISO-8601 date layout of the due dates.
+const DueLayout = time.DateOnly
+
+// dueInputLayouts are the date layouts accepted from the user, besides
+// the ISO-8601 one.
+var dueInputLayouts = []string{
+	DueLayout,
+	"2006/01/02",
+	"02.01.2006",
+	"Jan 2 2006",
+	"Jan 2, 2006",
+	"January 2 2006",
+	"January 2, 2006",
+	"2 Jan 2006",
+	"2 January 2006",
+}
+
+func parseDue(s string) (time.Time, error) {
+	return time.ParseInLocation(DueLayout, s, time.Local)
+}
+
+// ParseDue parses a due date typed by the user. Besides the dates in
+// a few common formats, it accepts "today" and "tomorrow" relative to
+// now.
+func ParseDue(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	y, m, d := now.Date()
+	switch strings.ToLower(s) {
+	case "today":
+		return time.Date(y, m, d, 0, 0, 0, 0, time.Local), nil
+	case "tomorrow":
+		return time.Date(y, m, d+1, 0, 0, 0, 0, time.Local), nil
+	}
+
+	for _, layout := range dueInputLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognized date %q", s)
+}
+
+// Due returns the item due date, or zero time if it has none.
+func (i *Item) Due() time.Time {
+	return i.due
+}
+
+// SetDue sets the item due date. Only the date part of the value
+// is kept.
+func (i *Item) SetDue(t time.Time) {
+	y, m, d := t.Date()
+	i.setDue(time.Date(y, m, d, 0, 0, 0, 0, time.Local))
+}
+
+// ClearDue removes the item due date.
+func (i *Item) ClearDue() {
+	i.setDue(time.Time{})
+}
+
+func (i *Item) setDue(due time.Time) {
+	old := i.due
+	if old.Equal(due) {
+		return
+	}
+
+	i.due = due
+	i.workspace.recordEdit(i, func() { i.due = old }, func() { i.due = due })
+}
+
+// DueWithin returns the items of the whole workspace tree which are
+// not completed and have a due date before now+d, including the past
+// ones. The items are sorted by the due date, keeping the document
+// order for the same dates.
+func (w *Workspace) DueWithin(d time.Duration, now time.Time) []*Item {
+	horizon := now.Add(d)
+
+	var items []*Item
+
+	var walk func(parent *Item)
+	walk = func(parent *Item) {
+		for c := parent.head; c != nil; c = c.next {
+			completed := c.status == StatusDone || c.status == StatusCanceled
+			if !c.due.IsZero() && !completed && c.due.Before(horizon) {
+				items = append(items, c)
+			}
+			walk(c)
+		}
+	}
+	walk(w.realRoot)
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].due.Before(items[j].due)
+	})
+
+	return items
+}
+
+// Agenda returns the actionable items of the whole workspace tree,
+// i.e. the ones to do, waiting or scheduled. The items are sorted by
+// the due date, the undated ones last, keeping the document order for
+// the same dates.
+func (w *Workspace) Agenda() []*Item {
+	var items []*Item
+	w.realRoot.Walk(func(c *Item) error {
+		switch c.status {
+		case StatusToDo, StatusWaiting, StatusScheduled:
+			items = append(items, c)
+		}
+		return nil
+	})
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i].due, items[j].due
+		if a.IsZero() || b.IsZero() {
+			return !a.IsZero() && b.IsZero()
+		}
+		return a.Before(b)
+	})
+
+	return items
+}
+
+// IsOverdue reports whether the item is not completed and its due date
+// is before the day of now. The day is taken in the location of the due
+// date, so the result does not depend on the location of now.
+func (i *Item) IsOverdue(now time.Time) bool {
+	if i.due.IsZero() || i.status == StatusDone || i.status == StatusCanceled {
+		return false
+	}
+
+	y, m, d := now.In(i.due.Location()).Date()
+	return i.due.Before(time.Date(y, m, d, 0, 0, 0, 0, i.due.Location()))
+}
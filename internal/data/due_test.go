@@ -0,0 +1,160 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestItemSetDue(t *testing.T) {
+	w, a, _, _ := newTestItems()
+	w.Root().Append(a)
+
+	a.SetDue(time.Date(2025, 6, 14, 15, 30, 0, 0, time.Local))
+	assert.Equal(t, time.Date(2025, 6, 14, 0, 0, 0, 0, time.Local), a.Due())
+
+	out, err := a.MarshalXMLBytes()
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `due="2025-06-14"`)
+
+	a.ClearDue()
+	assert.True(t, a.Due().IsZero())
+}
+
+func TestParseDue(t *testing.T) {
+	now := time.Date(2025, 6, 14, 10, 0, 0, 0, time.Local)
+	want := time.Date(2025, 7, 1, 0, 0, 0, 0, time.Local)
+
+	for _, s := range []string{
+		"2025-07-01",
+		" 2025/07/01 ",
+		"01.07.2025",
+		"Jul 1 2025",
+		"Jul 1, 2025",
+		"July 1 2025",
+		"1 Jul 2025",
+		"1 July 2025",
+	} {
+		due, err := data.ParseDue(s, now)
+		if assert.NoError(t, err, s) {
+			assert.Equal(t, want, due, s)
+		}
+	}
+
+	due, err := data.ParseDue("Today", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 14, 0, 0, 0, 0, time.Local), due)
+
+	due, err = data.ParseDue("tomorrow", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 15, 0, 0, 0, 0, time.Local), due)
+
+	for _, s := range []string{"", "soon", "2025-13-01", "32.01.2025"} {
+		_, err := data.ParseDue(s, now)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestWorkspaceDueWithin(t *testing.T) {
+	now := time.Date(2025, 6, 14, 10, 0, 0, 0, time.Local)
+	day := 24 * time.Hour
+
+	w, a, b, c := newTestItems()
+	root := w.Root()
+	d := w.NewItem("ChildD")
+	e := w.NewItem("ChildE")
+
+	root.Append(a)
+	a.Append(b)
+	root.Append(c)
+	root.Append(d)
+	root.Append(e)
+
+	a.SetDue(now.Add(3 * day))
+	b.SetDue(now.Add(-2 * day))
+	c.SetDue(now.Add(10 * day))
+	d.SetDue(now.Add(-5 * day))
+	d.SetStatus(data.StatusDone)
+	e.SetDue(now)
+
+	assert.Equal(t, []*data.Item{b, e, a}, w.DueWithin(7*day, now))
+	assert.Equal(t, []*data.Item{b, e}, w.DueWithin(day, now))
+	assert.Equal(t, []*data.Item{b, e, a, c}, w.DueWithin(30*day, now))
+}
+
+func TestWorkspaceAgenda(t *testing.T) {
+	now := time.Date(2025, 6, 14, 10, 0, 0, 0, time.Local)
+	day := 24 * time.Hour
+
+	w, a, b, c := newTestItems()
+	root := w.Root()
+	d := w.NewItem("ChildD")
+	e := w.NewItem("ChildE")
+	f := w.NewItem("ChildF")
+
+	root.Append(a)
+	a.Append(b)
+	root.Append(c)
+	root.Append(d)
+	d.Append(e)
+	root.Append(f)
+
+	a.SetStatus(data.StatusToDo)
+	b.SetStatus(data.StatusScheduled)
+	b.SetDue(now.Add(3 * day))
+	c.SetStatus(data.StatusWaiting)
+	c.SetDue(now.Add(-day))
+	d.SetStatus(data.StatusDone)
+	d.SetDue(now.Add(-2 * day))
+	e.SetStatus(data.StatusToDo)
+	f.SetDue(now)
+
+	// the undated items keep the document order, even below the
+	// completed ones
+	assert.Equal(t, []*data.Item{c, b, a, e}, w.Agenda())
+}
+
+func TestItemIsOverdue(t *testing.T) {
+	w, a, b, _ := newTestItems()
+	w.Root().Append(a)
+	w.Root().Append(b)
+
+	now := time.Date(2025, 6, 14, 9, 0, 0, 0, time.Local)
+
+	assert.False(t, a.IsOverdue(now), "no due date")
+
+	a.SetDue(now)
+	assert.False(t, a.IsOverdue(now), "due today")
+	assert.False(t, a.IsOverdue(now.Add(-24*time.Hour)), "due in the future")
+	assert.True(t, a.IsOverdue(now.Add(24*time.Hour)), "due yesterday")
+
+	// the day of now is taken in the location of the due date
+	east := time.FixedZone("east", 14*60*60)
+	b.SetDue(now)
+	assert.False(t, b.IsOverdue(time.Date(2025, 6, 14, 12, 0, 0, 0, time.Local).In(east)))
+
+	a.SetStatus(data.StatusDone)
+	assert.False(t, a.IsOverdue(now.Add(24*time.Hour)))
+	a.SetStatus(data.StatusCanceled)
+	assert.False(t, a.IsOverdue(now.Add(24*time.Hour)))
+	a.SetStatus(data.StatusWaiting)
+	assert.True(t, a.IsOverdue(now.Add(24*time.Hour)))
+}
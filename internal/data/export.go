@@ -0,0 +1,75 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+const textIndent = "  "
+
+// exporter writes the descendants of the root item to w.
+type exporter func(root *Item, w io.Writer) error
+
+// exporters maps the export format names to the exporters.
+var exporters = map[string]exporter{
+	"json":     ExportSubtreeJSON,
+	"text":     ExportText,
+	"markdown": ExportMarkdown,
+	"md":       ExportMarkdown,
+	"opml":     ExportSubtreeOPML,
+	"org":      ExportOrg,
+}
+
+// ExportFormats returns the names of the export formats, sorted.
+func ExportFormats() []string {
+	var formats []string
+	for name := range exporters {
+		formats = append(formats, name)
+	}
+	slices.Sort(formats)
+
+	return formats
+}
+
+// Export writes the descendants of root to w in the format, whose name
+// is case-insensitive.
+func Export(format string, root *Item, w io.Writer) error {
+	export, ok := exporters[strings.ToLower(format)]
+	if !ok {
+		return fmt.Errorf("unknown export format %q, expected one of: %s", format, strings.Join(ExportFormats(), ", "))
+	}
+
+	return export(root, w)
+}
+
+// ExportText writes the descendants of root as plain text, one item
+// per line, indented by two spaces per level below root. Items with
+// a status other than "None" are prefixed with the status keyword.
+// The collapsed state is ignored, so the whole subtree is written.
+func ExportText(root *Item, w io.Writer) error {
+	return root.Walk(func(c *Item) error {
+		title := c.Title()
+		if s := c.Status(); s != StatusNone {
+			title = s.String() + " " + title
+		}
+
+		_, err := fmt.Fprintln(w, strings.Repeat(textIndent, c.levelBelow(root))+title)
+		return err
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestExportText(t *testing.T) {
+	t.Run("EmptyRoot", func(t *testing.T) {
+		w, _, _, _ := newTestItems()
+
+		var buf bytes.Buffer
+		require.NoError(t, data.ExportText(w.Root(), &buf))
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("NestedItems", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+
+		root.Append(a)
+		a.Append(b)
+		root.Append(c)
+
+		b.SetStatus(data.StatusToDo)
+		a.SetCollapsed(true, false)
+
+		var buf bytes.Buffer
+		require.NoError(t, data.ExportText(root, &buf))
+		assert.Equal(t, "ChildA\n  TODO ChildB\nChildC\n", buf.String())
+	})
+
+	t.Run("ZoomedRoot", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+
+		root.Append(a)
+		a.Append(b)
+		b.Append(c)
+
+		var buf bytes.Buffer
+		require.NoError(t, data.ExportText(a, &buf))
+		assert.Equal(t, "ChildB\n  ChildC\n", buf.String())
+	})
+}
+
+func TestExport(t *testing.T) {
+	w, a, b, c := newTestItems()
+	w.Root().Append(a)
+	a.Append(b)
+	b.Append(c)
+	b.SetStatus(data.StatusDone)
+
+	for format, expected := range map[string]string{
+		"text":     "DONE ChildB\n  ChildC\n",
+		"markdown": "- [x] ChildB\n  - ChildC\n",
+		"MD":       "- [x] ChildB\n  - ChildC\n",
+		"opml":     `<outline text="ChildB" _status="DONE">`,
+		"org":      "* DONE ChildB\n** ChildC\n",
+		"json":     `"title": "ChildB",`,
+	} {
+		var buf bytes.Buffer
+		require.NoError(t, data.Export(format, a, &buf), format)
+		assert.Contains(t, buf.String(), expected, format)
+	}
+
+	var buf bytes.Buffer
+	assert.ErrorContains(t, data.Export("docx", a, &buf), "unknown export format")
+	assert.ErrorContains(t, data.Export("docx", a, &buf), "json, markdown, md, opml, org, text")
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	fuzzyScoreMatch       = 1
+	fuzzyScoreConsecutive = 2
+	fuzzyScoreWordStart   = 3
+)
+
+// FuzzyScore reports whether the query runes appear in s in the same
+// order, ignoring case, and returns the match score. Consecutive
+// matches and matches at the start of a word score higher. An empty
+// query matches any string with zero score.
+func FuzzyScore(query, s string) (int, bool) {
+	q := []rune(strings.ToLower(query))
+	if len(q) == 0 {
+		return 0, true
+	}
+
+	score := 0
+	qi := 0
+	prevMatched := false
+	prev := ' '
+
+	for _, r := range strings.ToLower(s) {
+		if qi < len(q) && r == q[qi] {
+			score += fuzzyScoreMatch
+			if prevMatched {
+				score += fuzzyScoreConsecutive
+			}
+			if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+				score += fuzzyScoreWordStart
+			}
+
+			qi++
+			prevMatched = true
+		} else {
+			prevMatched = false
+		}
+
+		prev = r
+	}
+
+	if qi < len(q) {
+		return 0, false
+	}
+
+	return score, true
+}
+
+// FuzzyFind returns all items of the workspace tree, except for the
+// real root, whose titles match the query, ordered by descending
+// score. Items with equal scores keep the document order.
+func (w *Workspace) FuzzyFind(query string) []*Item {
+	type match struct {
+		item  *Item
+		score int
+	}
+
+	var matches []match
+
+	var walk func(parent *Item)
+	walk = func(parent *Item) {
+		for c := parent.head; c != nil; c = c.next {
+			if score, ok := FuzzyScore(query, c.title); ok {
+				matches = append(matches, match{c, score})
+			}
+			walk(c)
+		}
+	}
+	walk(w.realRoot)
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	items := make([]*Item, len(matches))
+	for i, m := range matches {
+		items[i] = m.item
+	}
+
+	return items
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	t.Run("EmptyQuery", func(t *testing.T) {
+		score, ok := data.FuzzyScore("", "anything")
+		assert.True(t, ok)
+		assert.Zero(t, score)
+	})
+
+	t.Run("NotASubsequence", func(t *testing.T) {
+		_, ok := data.FuzzyScore("abc", "acb")
+		assert.False(t, ok)
+	})
+
+	t.Run("IgnoresCase", func(t *testing.T) {
+		_, ok := data.FuzzyScore("GRO", "groceries")
+		assert.True(t, ok)
+	})
+
+	t.Run("ConsecutiveBeatsScattered", func(t *testing.T) {
+		consecutive, ok := data.FuzzyScore("pro", "xproject")
+		assert.True(t, ok)
+
+		scattered, ok := data.FuzzyScore("pro", "xpxrxo")
+		assert.True(t, ok)
+
+		assert.Greater(t, consecutive, scattered)
+	})
+
+	t.Run("WordStartBeatsMiddle", func(t *testing.T) {
+		wordStart, _ := data.FuzzyScore("b", "a b")
+		middle, _ := data.FuzzyScore("b", "ab")
+
+		assert.Greater(t, wordStart, middle)
+	})
+}
+
+func TestWorkspaceFuzzyFind(t *testing.T) {
+	w, a, b, c := newTestItems()
+	root := w.Root()
+
+	a.SetTitle("Shopping")
+	b.SetTitle("Buy shoes")
+	c.SetTitle("Fix the car")
+
+	root.Append(a)
+	a.Append(b)
+	root.Append(c)
+
+	t.Run("RanksByScore", func(t *testing.T) {
+		items := w.FuzzyFind("sho")
+		assert.Equal(t, []*data.Item{a, b}, items)
+	})
+
+	t.Run("FiltersNonMatching", func(t *testing.T) {
+		items := w.FuzzyFind("car")
+		assert.Equal(t, []*data.Item{c}, items)
+	})
+
+	t.Run("EmptyQueryKeepsDocumentOrder", func(t *testing.T) {
+		items := w.FuzzyFind("")
+		assert.Equal(t, []*data.Item{a, b, c}, items)
+	})
+
+	t.Run("SearchesWholeTreeWhileZoomed", func(t *testing.T) {
+		w.SetRoot(a)
+		defer w.SetRoot(root)
+
+		items := w.FuzzyFind("fix")
+		assert.Equal(t, []*data.Item{c}, items)
+	})
+}
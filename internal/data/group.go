@@ -0,0 +1,165 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+const (
+	splitGroupFirst  = "Part 1"
+	splitGroupSecond = "Part 2"
+)
+
+// appendGroup appends a new child item with the given title and moves
+// the items under it, keeping their order. It returns the group.
+func (i *Item) appendGroup(title string, items []*Item) *Item {
+	g := i.workspace.NewItem(title)
+	i.Append(g)
+
+	for _, c := range items {
+		g.Append(c)
+	}
+
+	return g
+}
+
+// SplitSiblings replaces the children of the item parent with two
+// groups: the first one gets the siblings above the item, the second
+// one gets the item and the siblings below it. It does nothing and
+// returns nils if the item has no parent or no previous sibling.
+func (i *Item) SplitSiblings() (*Item, *Item) {
+	parent := i.parent
+	if parent == nil || i.prev == nil {
+		return nil, nil
+	}
+
+	defer i.workspace.batch()()
+
+	var above, below []*Item
+	for c := parent.head; c != i; c = c.next {
+		above = append(above, c)
+	}
+	for c := i; c != nil; c = c.next {
+		below = append(below, c)
+	}
+
+	return parent.appendGroup(splitGroupFirst, above), parent.appendGroup(splitGroupSecond, below)
+}
+
+// Flatten makes all the descendants of the item its children, in the
+// document order. The descendants are left without children, so their
+// collapsed flags are cleared. It reports whether there were any
+// grandchildren to promote.
+func (i *Item) Flatten() bool {
+	var descendants []*Item
+	nested := false
+
+	var walk func(parent *Item)
+	walk = func(parent *Item) {
+		for c := parent.head; c != nil; c = c.next {
+			descendants = append(descendants, c)
+			if c.head != nil {
+				nested = true
+				walk(c)
+			}
+		}
+	}
+	walk(i)
+
+	if !nested {
+		return false
+	}
+
+	defer i.workspace.batch()()
+
+	// the first child is already in place, and every next item in the
+	// document order goes below the previous one
+	for idx, d := range descendants[1:] {
+		d.MoveBelow(descendants[idx])
+	}
+
+	// the flattened items have no children to fold, and undo folds
+	// them again
+	for _, d := range descendants {
+		if d.collapsed {
+			d.collapsed = false
+			d.workspace.recordEdit(d, func() { d.collapsed = true }, func() { d.collapsed = false })
+		}
+	}
+
+	return true
+}
+
+// joinTitles joins the titles with a space, skipping the empty ones.
+func joinTitles(a, b string) string {
+	if a == "" || b == "" {
+		return a + b
+	}
+	return a + " " + b
+}
+
+// MergeIntoParent appends the item title to the title of its parent,
+// puts the item children in its place and detaches the item. It does
+// nothing and returns nil if the parent is the real root, otherwise it
+// returns the parent.
+func (i *Item) MergeIntoParent() *Item {
+	parent := i.parent
+	if parent == nil || parent.parent == nil {
+		return nil
+	}
+
+	defer i.workspace.batch()()
+
+	parent.SetTitle(joinTitles(parent.title, i.title))
+
+	for c := i.head; c != nil; {
+		next := c.next
+		c.MoveAbove(i)
+		c = next
+	}
+
+	i.Detach()
+
+	return parent
+}
+
+// JoinNext joins the item on the next outline row into the item, see
+// Join. It reports whether there was a row to join.
+func (i *Item) JoinNext() bool {
+	next := i.NextRow()
+	if next == nil {
+		return false
+	}
+
+	i.Join(next)
+
+	return true
+}
+
+// Join appends the title of the other item to the item title, moves
+// the other item children to the end of the item children list and
+// detaches the other item. The other item is usually on the next row:
+// the first child of the item, its next sibling, or the next sibling
+// of an ancestor. It must not be an ancestor of the item.
+func (i *Item) Join(other *Item) {
+	defer i.workspace.batch()()
+
+	i.SetTitle(joinTitles(i.title, other.title))
+
+	for c := other.head; c != nil; {
+		n := c.next
+		i.Append(c)
+		c = n
+	}
+
+	other.Detach()
+}
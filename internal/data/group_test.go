@@ -0,0 +1,279 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestItemSplitSiblings(t *testing.T) {
+	t.Run("Middle", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		d := w.NewItem("ChildD")
+		for _, item := range []*data.Item{a, b, c, d} {
+			w.Root().Append(item)
+		}
+
+		first, second := c.SplitSiblings()
+		require.NotNil(t, first)
+		require.NotNil(t, second)
+
+		assert.Equal(t, "Part 1", first.Title())
+		assert.Equal(t, "Part 2", second.Title())
+		assertChildrenOrder(t, w.Root(), first, second)
+		assertChildrenOrder(t, first, a, b)
+		assertChildrenOrder(t, second, c, d)
+	})
+
+	t.Run("Last", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		for _, item := range []*data.Item{a, b, c} {
+			w.Root().Append(item)
+		}
+
+		first, second := c.SplitSiblings()
+
+		assertChildrenOrder(t, w.Root(), first, second)
+		assertChildrenOrder(t, first, a, b)
+		assertChildrenOrder(t, second, c)
+		assert.Same(t, second, second.Parent().Tail())
+	})
+
+	t.Run("First", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		for _, item := range []*data.Item{a, b, c} {
+			w.Root().Append(item)
+		}
+
+		first, second := a.SplitSiblings()
+
+		assert.Nil(t, first)
+		assert.Nil(t, second)
+		assertChildrenOrder(t, w.Root(), a, b, c)
+	})
+}
+
+func TestItemFlatten(t *testing.T) {
+	t.Run("PreOrder", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+		d := w.NewItem("ChildD")
+		e := w.NewItem("ChildE")
+		other := w.NewItem("Other")
+
+		// root > a > (b > c, d > e), other
+		root.Append(a)
+		root.Append(other)
+		a.Append(b)
+		b.Append(c)
+		a.Append(d)
+		d.Append(e)
+		b.SetCollapsed(true, false)
+
+		require.True(t, root.Flatten())
+		assertChildrenOrder(t, root, a, b, c, d, e, other)
+		for _, item := range []*data.Item{a, b, c, d, e, other} {
+			assert.Nil(t, item.Head(), item.Title())
+			assert.False(t, item.Collapsed(), item.Title())
+		}
+
+		w.Undo()
+		assertChildrenOrder(t, root, a, other)
+		assertChildrenOrder(t, a, b, d)
+		assertChildrenOrder(t, b, c)
+		assertChildrenOrder(t, d, e)
+		assert.True(t, b.Collapsed())
+		assert.False(t, d.Collapsed())
+
+		w.Redo()
+		assertChildrenOrder(t, root, a, b, c, d, e, other)
+		assert.False(t, b.Collapsed())
+	})
+
+	t.Run("Subtree", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+		d := w.NewItem("ChildD")
+		root.Append(a)
+		root.Append(d)
+		a.Append(b)
+		b.Append(c)
+
+		require.True(t, a.Flatten())
+		assertChildrenOrder(t, root, a, d)
+		assertChildrenOrder(t, a, b, c)
+	})
+
+	t.Run("AlreadyFlat", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+		root.Append(a)
+		a.Append(b)
+		a.Append(c)
+
+		assert.False(t, a.Flatten())
+		assert.False(t, b.Flatten())
+		assertChildrenOrder(t, a, b, c)
+	})
+}
+
+func TestItemMergeIntoParent(t *testing.T) {
+	t.Run("SpliceChildren", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+		d := w.NewItem("ChildD")
+		e := w.NewItem("ChildE")
+		f := w.NewItem("ChildF")
+
+		// root > a > (b, c > (e, f), d)
+		root.Append(a)
+		a.Append(b)
+		a.Append(c)
+		a.Append(d)
+		c.Append(e)
+		c.Append(f)
+
+		assert.Same(t, a, c.MergeIntoParent())
+		assert.Equal(t, "ChildA ChildC", a.Title())
+		assertChildrenOrder(t, a, b, e, f, d)
+		assert.Nil(t, c.Parent())
+
+		w.Undo()
+		assert.Equal(t, "ChildA", a.Title())
+		assertChildrenOrder(t, a, b, c, d)
+		assertChildrenOrder(t, c, e, f)
+	})
+
+	t.Run("EmptyTitle", func(t *testing.T) {
+		w, a, b, _ := newTestItems()
+		w.Root().Append(a)
+		a.Append(b)
+		a.SetTitle("")
+
+		b.MergeIntoParent()
+		assert.Equal(t, "ChildB", a.Title())
+		assert.Nil(t, a.Head())
+	})
+
+	t.Run("TopLevel", func(t *testing.T) {
+		w, a, b, _ := newTestItems()
+		root := w.Root()
+		root.Append(a)
+		a.Append(b)
+
+		assert.Nil(t, a.MergeIntoParent())
+		assert.Equal(t, "Parent", root.Title())
+		assertChildrenOrder(t, root, a)
+		assertChildrenOrder(t, a, b)
+	})
+}
+
+func TestItemJoinNext(t *testing.T) {
+	t.Run("Leaf", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+		root.Append(a)
+		root.Append(b)
+		root.Append(c)
+
+		require.True(t, a.JoinNext())
+		assert.Equal(t, "ChildA ChildB", a.Title())
+		assertChildrenOrder(t, root, a, c)
+		assert.Nil(t, a.Head())
+		assert.Nil(t, b.Parent())
+		assert.Nil(t, b.Prev())
+		assert.Nil(t, b.Next())
+	})
+
+	t.Run("HasChildren", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+		d := w.NewItem("ChildD")
+		e := w.NewItem("ChildE")
+
+		// root > (a > c, b > (d, e)), a collapsed so b is on the next row
+		root.Append(a)
+		root.Append(b)
+		a.Append(c)
+		b.Append(d)
+		b.Append(e)
+		a.SetCollapsed(true, false)
+
+		require.True(t, a.JoinNext())
+		assertChildrenOrder(t, root, a)
+		assertChildrenOrder(t, a, c, d, e)
+		assert.Nil(t, b.Parent())
+		assert.Nil(t, b.Head())
+		assert.Nil(t, b.Tail())
+
+		w.Undo()
+		assert.Equal(t, "ChildA", a.Title())
+		assertChildrenOrder(t, root, a, b)
+		assertChildrenOrder(t, a, c)
+		assertChildrenOrder(t, b, d, e)
+	})
+
+	t.Run("FirstChild", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+		d := w.NewItem("ChildD")
+
+		// root > (a > c > d, b)
+		root.Append(a)
+		root.Append(b)
+		a.Append(c)
+		c.Append(d)
+
+		require.True(t, a.JoinNext())
+		assert.Equal(t, "ChildA ChildC", a.Title())
+		assertChildrenOrder(t, root, a, b)
+		assertChildrenOrder(t, a, d)
+		assert.Nil(t, c.Parent())
+	})
+
+	t.Run("LastChild", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+
+		// root > (a > c, b)
+		root.Append(a)
+		root.Append(b)
+		a.Append(c)
+
+		require.True(t, c.JoinNext())
+		assert.Equal(t, "ChildC ChildB", c.Title())
+		assertChildrenOrder(t, root, a)
+		assertChildrenOrder(t, a, c)
+		assert.Nil(t, b.Parent())
+	})
+
+	t.Run("Last", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+		root.Append(a)
+		root.Append(b)
+		b.Append(c)
+		b.SetCollapsed(true, false)
+
+		assert.False(t, b.JoinNext())
+		assert.Equal(t, "ChildB", b.Title())
+		assertChildrenOrder(t, root, a, b)
+	})
+}
@@ -0,0 +1,260 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+// maxHistory is the number of the undo steps kept by the workspace.
+const maxHistory = 1000
+
+// change is a reversible mutation of the tree. Both functions return
+// the item which should get the cursor afterwards.
+type change struct {
+	undo func() *Item
+	redo func() *Item
+}
+
+// history keeps the undo and redo stacks of the workspace. Every
+// stack entry is a batch of changes undone and redone as a single step.
+type history struct {
+	undo [][]change
+	redo [][]change
+
+	// nesting level of the open batches and their changes
+	depth   int
+	pending []change
+}
+
+func (w *Workspace) record(c change) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.dirty = true
+
+	if w.history.depth > 0 {
+		w.history.pending = append(w.history.pending, c)
+		return
+	}
+
+	w.pushHistory([]change{c})
+}
+
+func (w *Workspace) pushHistory(batch []change) {
+	h := &w.history
+
+	h.undo = append(h.undo, batch)
+	if len(h.undo) > maxHistory {
+		h.undo = h.undo[len(h.undo)-maxHistory:]
+	}
+
+	h.redo = nil
+}
+
+// BeginBatch starts recording the changes as a single undo step, until
+// the matching EndBatch call. Batches can be nested; only the outermost
+// one makes the step.
+func (w *Workspace) BeginBatch() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.history.depth++
+}
+
+// EndBatch ends the batch started with BeginBatch.
+func (w *Workspace) EndBatch() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	h := &w.history
+
+	h.depth--
+	if h.depth > 0 || len(h.pending) == 0 {
+		return
+	}
+
+	batch := h.pending
+	h.pending = nil
+	w.pushHistory(batch)
+}
+
+// batch is a shortcut for deferring the end of a batch:
+//
+//	defer w.batch()()
+func (w *Workspace) batch() func() {
+	w.BeginBatch()
+	return w.EndBatch
+}
+
+// clearHistory drops the recorded changes.
+func (w *Workspace) clearHistory() {
+	w.history = history{}
+}
+
+// Undo reverts the last recorded step and moves it to the redo stack.
+// It returns the item which should get the cursor, or nil if there is
+// nothing to undo. If the view root is removed from the tree, the view
+// is zoomed out to the real root.
+func (w *Workspace) Undo() *Item {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	h := &w.history
+	if len(h.undo) == 0 {
+		return nil
+	}
+
+	batch := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.redo = append(h.redo, batch)
+	w.dirty = true
+
+	hints := make([]*Item, 0, len(batch))
+	for idx := len(batch) - 1; idx >= 0; idx-- {
+		hints = append(hints, batch[idx].undo())
+	}
+
+	return w.afterHistory(hints)
+}
+
+// Redo applies the last undone step again. It returns the item which
+// should get the cursor, or nil if there is nothing to redo.
+func (w *Workspace) Redo() *Item {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	h := &w.history
+	if len(h.redo) == 0 {
+		return nil
+	}
+
+	batch := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.undo = append(h.undo, batch)
+	w.dirty = true
+
+	hints := make([]*Item, 0, len(batch))
+	for _, c := range batch {
+		hints = append(hints, c.redo())
+	}
+
+	return w.afterHistory(hints)
+}
+
+// afterHistory zooms out to the real root if the view root has left the
+// tree, and returns the item which should get the cursor.
+func (w *Workspace) afterHistory(hints []*Item) *Item {
+	if !w.root.inTree() {
+		w.root = w.realRoot
+	}
+
+	return w.cursorHint(hints)
+}
+
+// cursorHint returns the last of the hinted items which is in the tree,
+// falling back to the first real root child.
+func (w *Workspace) cursorHint(hints []*Item) *Item {
+	for idx := len(hints) - 1; idx >= 0; idx-- {
+		if item := hints[idx]; item != nil && item != w.realRoot && item.inTree() {
+			return item
+		}
+	}
+
+	return w.realRoot.head
+}
+
+// inTree reports whether the item is attached to the workspace tree.
+func (i *Item) inTree() bool {
+	for p := i; p != nil; p = p.parent {
+		if p == i.workspace.realRoot {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inWorkspace reports whether the item is attached to the workspace
+// tree or to the trash.
+func (i *Item) inWorkspace() bool {
+	for p := i; p != nil; p = p.parent {
+		if p == i.workspace.realRoot || p == i.workspace.trash {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recordMove returns a function recording the move of the item from
+// its current position to the one it has when the function is called:
+//
+//	defer i.workspace.recordMove(i)()
+func (w *Workspace) recordMove(i *Item) func() {
+	fromParent, fromPrev := i.parent, i.prev
+	attached := i.inWorkspace()
+
+	return func() {
+		w.mu.Lock()
+		w.updateIndex(i, attached)
+		w.mu.Unlock()
+
+		toParent, toPrev := i.parent, i.prev
+		if toParent == fromParent && toPrev == fromPrev {
+			return
+		}
+
+		w.record(change{
+			undo: func() *Item { return i.place(fromParent, fromPrev) },
+			redo: func() *Item { return i.place(toParent, toPrev) },
+		})
+	}
+}
+
+// recordEdit records a change of the item fields made by the redo
+// function and reverted by the undo one.
+func (w *Workspace) recordEdit(i *Item, undo, redo func()) {
+	w.record(change{
+		undo: func() *Item { undo(); return i },
+		redo: func() *Item { redo(); return i },
+	})
+}
+
+// place moves the item below prev in the children list of the parent,
+// or to its head if prev is nil. A nil parent detaches the item. It
+// returns the item, or the nearest row if the item is detached or
+// trashed.
+func (i *Item) place(parent, prev *Item) *Item {
+	defer i.workspace.updateIndex(i, i.inWorkspace())
+
+	hint := i
+	if parent == nil || parent == i.workspace.trash {
+		hint = i.next
+		if hint == nil {
+			hint = i.prev
+		}
+		if hint == nil {
+			hint = i.parent
+		}
+	}
+
+	switch {
+	case parent == nil:
+		i.detach()
+	case prev == nil:
+		parent.prependChild(i)
+	default:
+		i.moveBelow(prev)
+	}
+
+	return hint
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+// newHistoryWorkspace returns a workspace with three root children,
+// the middle one having two children of its own.
+func newHistoryWorkspace() (*data.Workspace, *data.Item, *data.Item, *data.Item) {
+	w, a, b, c := newTestItems()
+	w.Root().Append(a)
+	w.Root().Append(b)
+	w.Root().Append(c)
+
+	b.Append(w.NewItem("NestedA"))
+	b.Append(w.NewItem("NestedB"))
+	b.SetStatus(data.StatusToDo)
+
+	w.SetCursor(a)
+
+	return w, a, b, c
+}
+
+func marshalWorkspace(t *testing.T, w *data.Workspace) string {
+	t.Helper()
+
+	out, err := xml.MarshalIndent(w, "", "  ")
+	require.NoError(t, err)
+
+	return string(out)
+}
+
+func TestWorkspaceUndo(t *testing.T) {
+	t.Run("DeleteMiddleChild", func(t *testing.T) {
+		w, a, b, c := newHistoryWorkspace()
+		before := marshalWorkspace(t, w)
+
+		b.Detach()
+		assertChildrenOrder(t, w.Root(), a, c)
+
+		assert.Same(t, b, w.Undo())
+		assertChildrenOrder(t, w.Root(), a, b, c)
+		assert.Equal(t, before, marshalWorkspace(t, w))
+	})
+
+	t.Run("Redo", func(t *testing.T) {
+		w, a, b, c := newHistoryWorkspace()
+
+		b.Detach()
+		after := marshalWorkspace(t, w)
+
+		w.Undo()
+		assert.Same(t, c, w.Redo())
+		assertChildrenOrder(t, w.Root(), a, c)
+		assertItemDetached(t, b)
+		assert.Equal(t, after, marshalWorkspace(t, w))
+	})
+
+	t.Run("Edits", func(t *testing.T) {
+		w, a, _, _ := newHistoryWorkspace()
+
+		a.SetTitle("Renamed")
+		a.SetStatus(data.StatusDone)
+		a.AddTag("work")
+
+		w.Undo()
+		assert.Empty(t, a.Tags())
+		w.Undo()
+		assert.Equal(t, data.StatusNone, a.Status())
+		w.Undo()
+		assert.Equal(t, "ChildA", a.Title())
+
+		w.Redo()
+		assert.Equal(t, "Renamed", a.Title())
+	})
+
+	t.Run("NewChangeClearsRedo", func(t *testing.T) {
+		w, a, _, _ := newHistoryWorkspace()
+
+		a.SetTitle("Renamed")
+		w.Undo()
+		a.SetStatus(data.StatusDone)
+
+		assert.Nil(t, w.Redo())
+		assert.Equal(t, "ChildA", a.Title())
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		w, a, b, c := newHistoryWorkspace()
+		before := marshalWorkspace(t, w)
+
+		w.BeginBatch()
+		b.Demote()
+		c.MoveAbove(a)
+		w.BeginBatch()
+		a.SetTitle("Renamed")
+		w.EndBatch()
+		w.EndBatch()
+
+		w.Undo()
+		assert.Equal(t, before, marshalWorkspace(t, w))
+	})
+
+	t.Run("CompoundOperation", func(t *testing.T) {
+		w, _, b, _ := newHistoryWorkspace()
+		before := marshalWorkspace(t, w)
+
+		b.SplitSiblings()
+		w.Undo()
+
+		assert.Equal(t, before, marshalWorkspace(t, w))
+	})
+
+	t.Run("RemovedViewRoot", func(t *testing.T) {
+		w, _, b, _ := newHistoryWorkspace()
+		realRoot := w.Root()
+
+		item := w.NewItem("New")
+		b.Append(item)
+		w.SetRoot(item)
+
+		cursor := w.Undo()
+		assert.Same(t, b.Tail(), cursor)
+		assert.Equal(t, "NestedB", cursor.Title())
+		assert.Same(t, realRoot, w.Root())
+	})
+
+	t.Run("NothingToUndo", func(t *testing.T) {
+		w, _, _, _ := newTestItems()
+
+		assert.Nil(t, w.Undo())
+		assert.Nil(t, w.Redo())
+	})
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+)
+
+// SaveWith saves the workspace with the data produced by the write
+// function, letting the tests inject write failures.
+func (w *Workspace) SaveWith(write func(f io.Writer) error) error {
+	return w.save(write)
+}
+
+// DisableHardLinks makes the hard links fail until the test ends, as
+// on the filesystems which do not support them.
+func DisableHardLinks(t *testing.T) {
+	t.Cleanup(func() { link = os.Link })
+	link = func(string, string) error { return errors.New("hard links are not supported") }
+}
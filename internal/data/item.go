@@ -18,7 +18,10 @@ import (
 	"encoding/xml"
 	"errors"
 	"io"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -49,12 +52,36 @@ type Item struct {
 
 	// data fields
 	title     string
+	note      string
 	status    Status
+	priority  Priority
 	collapsed bool
+	tags      []string
+	due       time.Time
+
+	// ids of the items referred to by the item
+	refs []uuid.UUID
+
+	// creation and last title, note or status change times
+	created  time.Time
+	modified time.Time
+
+	// time the item was marked done
+	completed time.Time
+
+	// time the item was moved to the trash and the id of the parent
+	// it was deleted from
+	deleted time.Time
+	origin  uuid.UUID
 }
 
 // Detach detaches the item from its parent and siblings.
 func (i *Item) Detach() {
+	defer i.workspace.recordMove(i)()
+	i.detach()
+}
+
+func (i *Item) detach() {
 	if i.prev != nil {
 		i.prev.next = i.next
 	} else if i.parent != nil {
@@ -74,7 +101,12 @@ func (i *Item) Detach() {
 
 // MoveAbove moves item above the target.
 func (i *Item) MoveAbove(target *Item) {
-	i.Detach()
+	defer i.workspace.recordMove(i)()
+	i.moveAbove(target)
+}
+
+func (i *Item) moveAbove(target *Item) {
+	i.detach()
 
 	i.parent = target.parent
 	i.prev = target.prev
@@ -91,7 +123,12 @@ func (i *Item) MoveAbove(target *Item) {
 
 // MoveBelow moves item below the target.
 func (i *Item) MoveBelow(target *Item) {
-	i.Detach()
+	defer i.workspace.recordMove(i)()
+	i.moveBelow(target)
+}
+
+func (i *Item) moveBelow(target *Item) {
+	i.detach()
 
 	i.parent = target.parent
 	i.prev = target
@@ -109,12 +146,21 @@ func (i *Item) MoveBelow(target *Item) {
 // Prepend places the provided item in the head position
 // of the visitor's children list.
 func (i *Item) Prepend(item *Item) {
+	defer i.workspace.recordMove(item)()
+	i.prependChild(item)
+}
+
+func (i *Item) prependChild(item *Item) {
+	if i.head == item {
+		return
+	}
+
 	if i.head != nil {
-		item.MoveAbove(i.head)
+		item.moveAbove(i.head)
 		return
 	}
 
-	item.Detach()
+	item.detach()
 
 	item.parent = i
 	i.head = item
@@ -124,12 +170,21 @@ func (i *Item) Prepend(item *Item) {
 // Append places the provided item in the tail position
 // of the visitor's children list.
 func (i *Item) Append(item *Item) {
+	defer i.workspace.recordMove(item)()
+	i.appendChild(item)
+}
+
+func (i *Item) appendChild(item *Item) {
+	if i.tail == item {
+		return
+	}
+
 	if i.tail != nil {
-		item.MoveBelow(i.tail)
+		item.moveBelow(i.tail)
 		return
 	}
 
-	item.Detach()
+	item.detach()
 
 	item.parent = i
 	i.head = item
@@ -152,13 +207,29 @@ func (i *Item) MoveDown() {
 	}
 }
 
+// MoveToTop places item at the head of its siblings list.
+// If the item is already there or has no parent, this method does nothing.
+func (i *Item) MoveToTop() {
+	if i.parent != nil && i.prev != nil {
+		i.parent.Prepend(i)
+	}
+}
+
+// MoveToBottom places item at the tail of its siblings list.
+// If the item is already there or has no parent, this method does nothing.
+func (i *Item) MoveToBottom() {
+	if i.parent != nil && i.next != nil {
+		i.parent.Append(i)
+	}
+}
+
 func (i *Item) Demote() {
 	prev := i.prev
 	if prev == nil {
 		return
 	}
 
-	prev.collapsed = false
+	prev.setCollapsed(false)
 	prev.Append(i)
 }
 
@@ -218,6 +289,35 @@ func (i *Item) NextRow() *Item {
 	return nil
 }
 
+// NextWithStatus returns the item with the status on the nearest of the
+// next outline rows, or nil if there is none.
+func (i *Item) NextWithStatus(s Status) *Item {
+	for r := i.NextRow(); r != nil; r = r.NextRow() {
+		if r.status == s {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// PrevWithStatus returns the item with the status on the nearest of the
+// previous outline rows, or nil if there is none.
+func (i *Item) PrevWithStatus(s Status) *Item {
+	for r := i.PrevRow(); r != nil; r = r.PrevRow() {
+		if r.status == s {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// ID returns the item unique id.
+func (i *Item) ID() uuid.UUID {
+	return i.id
+}
+
 func (i *Item) Parent() *Item {
 	return i.parent
 }
@@ -240,6 +340,23 @@ func (i *Item) Status() Status {
 	return i.status
 }
 
+// Created returns the time the item was created.
+func (i *Item) Created() time.Time {
+	return i.created
+}
+
+// Modified returns the time the item title or status was last changed.
+func (i *Item) Modified() time.Time {
+	return i.modified
+}
+
+// Completed returns the time the item was marked done, or the zero
+// time if it is not completed. The canceled items keep the time they
+// were done at.
+func (i *Item) Completed() time.Time {
+	return i.completed
+}
+
 // Collapsed returns the item "collapsed" flag value.
 func (i *Item) Collapsed() bool {
 	return i.collapsed
@@ -268,38 +385,127 @@ func (i *Item) Depth() int {
 	return depth
 }
 
+// levelBelow returns the number of items between the item and its
+// ancestor, 0 for the children of the ancestor.
+func (i *Item) levelBelow(ancestor *Item) int {
+	level := 0
+	for p := i.parent; p != nil && p != ancestor; p = p.parent {
+		level++
+	}
+
+	return level
+}
+
+// IsDescendantOf reports whether the item is in the subtree of the
+// ancestor, not counting the ancestor itself.
+func (i *Item) IsDescendantOf(ancestor *Item) bool {
+	for p := i.parent; p != nil; p = p.parent {
+		if p == ancestor {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ToDoStats returns the number of children in statuses "Done"
 // or "Canceled" and the number of children in statuses other
 // than "None".
 func (item *Item) ToDoStats() (int, int) {
 	var completed, total int
-	for c := item.Head(); c != nil; c = c.Next() {
-		s := c.Status()
+	item.Walk(func(c *Item) error {
+		completed, total = countToDo(c, completed, total)
+		return SkipChildren
+	})
 
-		if s != StatusNone {
-			total++
-		}
+	return completed, total
+}
 
-		if s == StatusDone || s == StatusCanceled {
-			completed++
-		}
+// countToDo adds the item to the completed and total counts of the
+// to-do statistics.
+func countToDo(c *Item, completed, total int) (int, int) {
+	if c.status != StatusNone {
+		total++
+	}
+
+	if c.status == StatusDone || c.status == StatusCanceled {
+		completed++
 	}
 
 	return completed, total
 }
 
+// ToDoStatsDeep is ToDoStats counting all the descendants of the item
+// rather than the children.
+func (item *Item) ToDoStatsDeep() (int, int) {
+	var completed, total int
+	item.Walk(func(c *Item) error {
+		completed, total = countToDo(c, completed, total)
+		return nil
+	})
+
+	return completed, total
+}
+
+// ChildCount returns the number of the item direct children.
+func (i *Item) ChildCount() int {
+	n := 0
+	for c := i.head; c != nil; c = c.next {
+		n++
+	}
+
+	return n
+}
+
 // DisplayedChildren returns a flattened list of non-collapsed
 // child items.
 func (i *Item) DisplayedChildren() []*Item {
-	var items []*Item
-	for c := i.Head(); c != nil; c = c.Next() {
-		items = append(items, c)
+	return i.AppendDisplayed(nil)
+}
+
+// AppendDisplayed appends the items returned by DisplayedChildren to
+// the buffer and returns the extended buffer. Passing the buffer of the
+// previous call truncated to zero length saves the allocations.
+func (i *Item) AppendDisplayed(buf []*Item) []*Item {
+	return i.AppendFiltered(buf, nil)
+}
+
+// FilteredChildren returns a flattened list of non-collapsed child
+// items, leaving out the items for which hidden returns true together
+// with their subtrees. A nil hidden function hides nothing.
+func (i *Item) FilteredChildren(hidden func(*Item) bool) []*Item {
+	return i.AppendFiltered(nil, hidden)
+}
 
-		if !c.Collapsed() && c.Head() != nil {
-			items = append(items, c.DisplayedChildren()...)
+// AppendFiltered is AppendDisplayed for FilteredChildren.
+func (i *Item) AppendFiltered(buf []*Item, hidden func(*Item) bool) []*Item {
+	i.WalkDisplayed(func(c *Item) error {
+		if hidden != nil && hidden(c) {
+			return SkipChildren
 		}
+
+		buf = append(buf, c)
+		return nil
+	})
+
+	return buf
+}
+
+// Search returns the descendants of the item whose titles contain the
+// query, ignoring case, in the document order. The collapsed state is
+// ignored.
+func (i *Item) Search(query string) []*Item {
+	query = strings.ToLower(query)
+
+	var matches []*Item
+	for c := i.head; c != nil; c = c.next {
+		if strings.Contains(strings.ToLower(c.title), query) {
+			matches = append(matches, c)
+		}
+		matches = append(matches, c.Search(query)...)
 	}
-	return items
+
+	return matches
 }
 
 // RealRoot returns the root of the tree the item belongs to.
@@ -323,26 +529,112 @@ func (i *Item) Next() *Item {
 	return i.next
 }
 
-// SetTitle updates the item title value and marks the item as dirty.
+// SetTitle updates the item title value and records the change
+// for undo.
 func (i *Item) SetTitle(val string) {
+	old := i.title
+	if old == val {
+		return
+	}
+
 	i.title = val
+	i.modified = time.Now()
+	i.workspace.recordEdit(i, func() { i.title = old }, func() { i.title = val })
+}
+
+// Note returns the multi-line text attached to the item.
+func (i *Item) Note() string {
+	return i.note
+}
+
+// SetNote updates the item note and records the change for undo.
+func (i *Item) SetNote(val string) {
+	old := i.note
+	if old == val {
+		return
+	}
+
+	i.note = val
+	i.modified = time.Now()
+	i.workspace.recordEdit(i, func() { i.note = old }, func() { i.note = val })
 }
 
-// SetStatus toggles the item "done" flag value and marks the
-// item as dirty.
+// SetStatus updates the item status value and records the change
+// for undo. If auto-completion is enabled, the ancestors are updated
+// too, and undone along with the item.
 func (i *Item) SetStatus(s Status) {
+	if i.status == s {
+		return
+	}
+
+	if !i.workspace.autoComplete {
+		i.setStatus(s)
+		return
+	}
+
+	defer i.workspace.batch()()
+
+	i.setStatus(s)
+	i.syncAncestors()
+}
+
+// SetStatusRecursive sets the status of the item and all its
+// descendants as a single undo step.
+func (i *Item) SetStatusRecursive(s Status) {
+	defer i.workspace.batch()()
+
+	i.SetStatus(s)
+	for c := i.head; c != nil; c = c.next {
+		c.SetStatusRecursive(s)
+	}
+}
+
+// setStatus sets the status, stamping the completion time when the
+// item is marked done. Canceling the done item keeps the time, while
+// reopening it clears the time.
+func (i *Item) setStatus(s Status) {
+	old, oldCompleted := i.status, i.completed
+
+	completed := time.Time{}
+	switch s {
+	case StatusDone:
+		completed = time.Now()
+	case StatusCanceled:
+		completed = i.completed
+	}
+
 	i.status = s
+	i.completed = completed
+	i.modified = time.Now()
+	i.workspace.recordEdit(i,
+		func() { i.status, i.completed = old, oldCompleted },
+		func() { i.status, i.completed = s, completed },
+	)
+}
+
+// syncAncestors completes the ancestors whose children with a status
+// are all completed, and reopens the done ones having the children
+// which are not. Only the ancestors with a status are updated, so the
+// walk up stops at the first one without a status or left unchanged.
+func (i *Item) syncAncestors() {
+	for p := i.parent; p != nil && p.status != StatusNone; p = p.parent {
+		completed, total := p.ToDoStats()
+		switch {
+		case total > 0 && completed == total && p.status != StatusDone && p.status != StatusCanceled:
+			p.setStatus(StatusDone)
+		case completed < total && p.status == StatusDone:
+			p.setStatus(StatusToDo)
+		default:
+			return
+		}
+	}
 }
 
 // SetCollapsed set the item "collapsed" flag value. If recursive is true
 // it walks through the child items as well.
 func (i *Item) SetCollapsed(value, recursive bool) {
 	// collapse only items with children
-	if value == true && i.head != nil {
-		i.collapsed = true
-	} else {
-		i.collapsed = false
-	}
+	i.setCollapsed(value && i.head != nil)
 
 	if recursive {
 		for c := i.head; c != nil; c = c.next {
@@ -351,6 +643,139 @@ func (i *Item) SetCollapsed(value, recursive bool) {
 	}
 }
 
+// setCollapsed sets the item "collapsed" flag value. The flag is
+// stored in the workspace file, so changing it makes the workspace
+// dirty, but it is not undoable, as the other view state.
+func (i *Item) setCollapsed(value bool) {
+	if i.collapsed == value {
+		return
+	}
+
+	i.collapsed = value
+	i.workspace.markDirty()
+}
+
+// ExpandToLevel expands the descendants of the item up to the given
+// level, the children being the level 1, and collapses the deeper ones,
+// so n levels are displayed.
+func (i *Item) ExpandToLevel(n int) {
+	for c := i.head; c != nil; c = c.next {
+		c.SetCollapsed(n <= 1, false)
+		c.ExpandToLevel(n - 1)
+	}
+}
+
+// CompleteWithAncestors marks the item and its ancestors up to, but not
+// including, the stop item as done. Unless all is true, only the items
+// which have a status are marked, so the structural ones stay as is.
+func (i *Item) CompleteWithAncestors(stop *Item, all bool) {
+	defer i.workspace.batch()()
+
+	for p := i; p != nil && p != stop; p = p.parent {
+		if all || p.status != StatusNone {
+			p.SetStatus(StatusDone)
+		}
+	}
+}
+
+// FindOrCreateChild returns the first child item with the given
+// title. If there is no such child, a new one is appended to the
+// children list. The second return value reports if it was created.
+func (i *Item) FindOrCreateChild(title string) (*Item, bool) {
+	for c := i.head; c != nil; c = c.next {
+		if c.title == title {
+			return c, false
+		}
+	}
+
+	c := i.workspace.NewItem(title)
+	i.Append(c)
+
+	return c, true
+}
+
+// Reflow splits the item title into paragraphs, keeps the first one
+// as the title and appends the rest as new child items. It returns the
+// created children, or nil if the title has a single paragraph.
+func (i *Item) Reflow() []*Item {
+	paragraphs := SplitParagraphs(i.title)
+	if len(paragraphs) < 2 {
+		return nil
+	}
+
+	defer i.workspace.batch()()
+
+	i.SetTitle(paragraphs[0])
+
+	var children []*Item
+	for _, p := range paragraphs[1:] {
+		c := i.workspace.NewItem(p)
+		i.Append(c)
+		children = append(children, c)
+	}
+
+	return children
+}
+
+// SortChildren reorders the item children according to the less
+// function, keeping the order of the equal ones. The descendants are
+// not sorted.
+func (i *Item) SortChildren(less func(a, b *Item) bool) {
+	var children []*Item
+	for c := i.head; c != nil; c = c.next {
+		children = append(children, c)
+	}
+
+	sorted := slices.IsSortedFunc(children, func(a, b *Item) int {
+		return compareWith(less, a, b)
+	})
+	if sorted {
+		return
+	}
+
+	slices.SortStableFunc(children, func(a, b *Item) int {
+		return compareWith(less, a, b)
+	})
+
+	defer i.workspace.batch()()
+
+	for _, c := range children {
+		i.Append(c)
+	}
+}
+
+func compareWith(less func(a, b *Item) bool, a, b *Item) int {
+	switch {
+	case less(a, b):
+		return -1
+	case less(b, a):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Clone returns a detached deep copy of the item and its descendants,
+// created in the workspace with fresh ids. The copy shares no items
+// with the original, so it can be appended anywhere.
+func (i *Item) Clone(w *Workspace) *Item {
+	c := w.NewItem(i.title)
+	c.note = i.note
+	c.status = i.status
+	c.completed = i.completed
+	c.priority = i.priority
+	c.collapsed = i.collapsed
+	c.tags = slices.Clone(i.tags)
+	c.due = i.due
+	c.refs = slices.Clone(i.refs)
+
+	for child := i.head; child != nil; child = child.next {
+		c.appendChild(child.Clone(w))
+	}
+
+	return c
+}
+
 func newTrueAttr(name string) xml.Attr {
 	return xml.Attr{
 		Name:  xml.Name{Local: name},
@@ -372,10 +797,62 @@ func (i *Item) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		})
 	}
 
+	if i.priority != PriorityNone {
+		start.Attr = append(start.Attr, xml.Attr{
+			Name:  xml.Name{Local: xmlItemAttrPriority},
+			Value: i.priority.String(),
+		})
+	}
+
 	if i.collapsed {
 		start.Attr = append(start.Attr, newTrueAttr(xmlItemAttrCollapsed))
 	}
 
+	if len(i.tags) > 0 {
+		start.Attr = append(start.Attr, xml.Attr{
+			Name:  xml.Name{Local: xmlItemAttrTags},
+			Value: strings.Join(i.tags, tagSeparator),
+		})
+	}
+
+	if !i.due.IsZero() {
+		start.Attr = append(start.Attr, xml.Attr{
+			Name:  xml.Name{Local: xmlItemAttrDue},
+			Value: i.due.Format(DueLayout),
+		})
+	}
+
+	if !i.completed.IsZero() {
+		start.Attr = append(start.Attr, xml.Attr{
+			Name:  xml.Name{Local: xmlItemAttrCompleted},
+			Value: i.completed.Format(time.RFC3339),
+		})
+	}
+
+	start.Attr = append(start.Attr,
+		xml.Attr{
+			Name:  xml.Name{Local: xmlItemAttrCreated},
+			Value: i.created.Format(time.RFC3339),
+		},
+		xml.Attr{
+			Name:  xml.Name{Local: xmlItemAttrModified},
+			Value: i.modified.Format(time.RFC3339),
+		},
+	)
+
+	if !i.deleted.IsZero() {
+		start.Attr = append(start.Attr,
+			xml.Attr{
+				Name:  xml.Name{Local: xmlItemAttrDeleted},
+				Value: i.deleted.Format(time.RFC3339),
+			},
+			xml.Attr{
+				Name:  xml.Name{Local: xmlItemAttrOrigin},
+				Value: i.origin.String(),
+			},
+		)
+	}
+
 	if err := e.EncodeToken(start); err != nil {
 		return err
 	}
@@ -384,6 +861,25 @@ func (i *Item) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		return err
 	}
 
+	if i.note != "" {
+		if err := e.EncodeElement(i.note, xml.StartElement{Name: xml.Name{Local: xmlElemNote}}); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range i.refs {
+		ref := xml.StartElement{
+			Name: xml.Name{Local: xmlElemRef},
+			Attr: []xml.Attr{{Name: xml.Name{Local: xmlRefAttrId}, Value: id.String()}},
+		}
+		if err := e.EncodeToken(ref); err != nil {
+			return err
+		}
+		if err := e.EncodeToken(ref.End()); err != nil {
+			return err
+		}
+	}
+
 	for c := i.head; c != nil; c = c.Next() {
 		if err := e.Encode(c); err != nil {
 			return err
@@ -393,31 +889,91 @@ func (i *Item) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	return e.EncodeToken(xml.EndElement{Name: start.Name})
 }
 
+// MarshalXMLBytes returns the indented XML of the item subtree, in the
+// same form the item is stored in the workspace file.
+func (i *Item) MarshalXMLBytes() ([]byte, error) {
+	return xml.MarshalIndent(i, "", "  ")
+}
+
+// UnmarshalXML decodes the item. The items of the files written before
+// the timestamps were stored keep the creation time set by NewItem,
+// i.e. the load time, and are considered modified at creation.
 func (i *Item) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	hasID, hasModified := false, false
+
 	for _, attr := range start.Attr {
 		switch attr.Name.Local {
 		case xmlItemAttrId:
-			var err error
-			i.id, err = uuid.Parse(attr.Value)
+			id, err := uuid.Parse(attr.Value)
 			if err != nil {
 				return err
 			}
+
+			i.id = id
+			hasID = true
 		case xmlItemAttrStatus:
 			var err error
 			i.status, err = ParseStatus(attr.Value)
 			if err != nil {
 				return err
 			}
+		case xmlItemAttrPriority:
+			var err error
+			i.priority, err = ParsePriority(attr.Value)
+			if err != nil {
+				return err
+			}
 		case xmlItemAttrCollapsed:
 			i.collapsed = true
+		case xmlItemAttrTags:
+			i.tags = parseTags(attr.Value)
+		case xmlItemAttrDue:
+			var err error
+			i.due, err = parseDue(attr.Value)
+			if err != nil {
+				return err
+			}
+		case xmlItemAttrCreated:
+			var err error
+			i.created, err = time.Parse(time.RFC3339, attr.Value)
+			if err != nil {
+				return err
+			}
+		case xmlItemAttrModified:
+			var err error
+			i.modified, err = time.Parse(time.RFC3339, attr.Value)
+			if err != nil {
+				return err
+			}
+			hasModified = true
+		case xmlItemAttrCompleted:
+			var err error
+			i.completed, err = time.Parse(time.RFC3339, attr.Value)
+			if err != nil {
+				return err
+			}
+		case xmlItemAttrDeleted:
+			var err error
+			i.deleted, err = time.Parse(time.RFC3339, attr.Value)
+			if err != nil {
+				return err
+			}
+		case xmlItemAttrOrigin:
+			var err error
+			i.origin, err = uuid.Parse(attr.Value)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
-	if i.id == uuid.Nil {
-		return errMissingItemId
+	if !hasModified {
+		i.modified = i.created
 	}
 
-	i.workspace.itemIndex[i.id] = i
+	if !hasID {
+		return errMissingItemId
+	}
 
 	for {
 		tok, err := d.Token()
@@ -435,6 +991,23 @@ func (i *Item) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 				if err := d.DecodeElement(&i.title, &se); err != nil {
 					return err
 				}
+			case xmlElemNote:
+				if err := d.DecodeElement(&i.note, &se); err != nil {
+					return err
+				}
+			case xmlElemRef:
+				for _, attr := range se.Attr {
+					if attr.Name.Local == xmlRefAttrId {
+						id, err := uuid.Parse(attr.Value)
+						if err != nil {
+							return err
+						}
+						i.refs = append(i.refs, id)
+					}
+				}
+				if err := d.Skip(); err != nil {
+					return err
+				}
 			case xmlElemItem:
 				c := i.workspace.NewItem("")
 				if err := d.DecodeElement(c, &se); err != nil {
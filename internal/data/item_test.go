@@ -15,7 +15,13 @@
 package data_test
 
 import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -165,6 +171,137 @@ func TestItemMoveDown(t *testing.T) {
 	})
 }
 
+func TestItemMoveToTop(t *testing.T) {
+	t.Run("AtHead", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+
+		root.Append(a)
+		root.Append(b)
+		root.Append(c)
+
+		a.MoveToTop()
+
+		assertChildrenOrder(t, root, a, b, c)
+	})
+
+	t.Run("Middle", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+
+		root.Append(a)
+		root.Append(b)
+		root.Append(c)
+
+		b.MoveToTop()
+		assertChildrenOrder(t, root, b, a, c)
+
+		c.MoveToTop()
+		assertChildrenOrder(t, root, c, b, a)
+	})
+}
+
+func TestItemMoveToBottom(t *testing.T) {
+	t.Run("AtTail", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+
+		root.Append(a)
+		root.Append(b)
+		root.Append(c)
+
+		c.MoveToBottom()
+
+		assertChildrenOrder(t, root, a, b, c)
+	})
+
+	t.Run("Middle", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+
+		root.Append(a)
+		root.Append(b)
+		root.Append(c)
+
+		b.MoveToBottom()
+		assertChildrenOrder(t, root, a, c, b)
+
+		a.MoveToBottom()
+		assertChildrenOrder(t, root, c, b, a)
+	})
+}
+
+func TestItemExpandToLevel(t *testing.T) {
+	w, a, b, c := newTestItems()
+	root := w.Root()
+	d := w.NewItem("ChildD")
+	leaf := w.NewItem("Leaf")
+
+	// Parent
+	//   A
+	//     B
+	//       C
+	//         D
+	//   Leaf
+	root.Append(a)
+	root.Append(leaf)
+	a.Append(b)
+	b.Append(c)
+	c.Append(d)
+	a.SetCollapsed(true, true)
+
+	root.ExpandToLevel(2)
+	assert.Equal(t, []*data.Item{a, b, leaf}, root.DisplayedChildren())
+	assert.False(t, a.Collapsed())
+	assert.True(t, b.Collapsed())
+	assert.True(t, c.Collapsed())
+
+	// the leaves are never collapsed
+	root.ExpandToLevel(1)
+	assert.Equal(t, []*data.Item{a, leaf}, root.DisplayedChildren())
+	assert.False(t, leaf.Collapsed())
+
+	root.ExpandToLevel(9)
+	assert.Equal(t, []*data.Item{a, b, c, d, leaf}, root.DisplayedChildren())
+}
+
+func TestItemNextWithStatus(t *testing.T) {
+	w, a, b, c := newTestItems()
+	root := w.Root()
+	d := w.NewItem("ChildD")
+	hidden := w.NewItem("Hidden")
+
+	// Parent
+	//   A
+	//     B (collapsed)
+	//       Hidden
+	//   C
+	//   D
+	root.Append(a)
+	root.Append(c)
+	root.Append(d)
+	a.Append(b)
+	b.Append(hidden)
+	b.SetCollapsed(true, false)
+	a.SetStatus(data.StatusToDo)
+	hidden.SetStatus(data.StatusToDo)
+	d.SetStatus(data.StatusToDo)
+
+	assert.Same(t, d, a.NextWithStatus(data.StatusToDo))
+	assert.Nil(t, d.NextWithStatus(data.StatusToDo))
+	assert.Same(t, a, d.PrevWithStatus(data.StatusToDo))
+	assert.Nil(t, a.PrevWithStatus(data.StatusToDo))
+	assert.Same(t, b, a.NextWithStatus(data.StatusNone))
+	assert.Same(t, c, d.PrevWithStatus(data.StatusNone))
+
+	// the scan stays under the view root
+	w.SetRoot(a)
+	b.SetCollapsed(false, false)
+	assert.Same(t, hidden, b.NextWithStatus(data.StatusToDo))
+	assert.Nil(t, hidden.NextWithStatus(data.StatusToDo))
+	assert.Nil(t, b.PrevWithStatus(data.StatusToDo))
+}
+
 func TestItemDemote(t *testing.T) {
 	t.Run("NilPrev", func(t *testing.T) {
 		w, a, _, _ := newTestItems()
@@ -329,6 +466,36 @@ func TestItemRealRoot(t *testing.T) {
 	assert.Same(t, root, c.RealRoot())
 }
 
+func TestItemSetStatusRecursive(t *testing.T) {
+	w, a, b, c := newTestItems()
+	root := w.Root()
+	nested := w.NewItem("Nested")
+	root.Append(a)
+	root.Append(c)
+	a.Append(b)
+	b.Append(nested)
+	c.SetStatus(data.StatusToDo)
+
+	modified := nested.Modified()
+	time.Sleep(time.Millisecond)
+
+	a.SetStatusRecursive(data.StatusCanceled)
+	for _, item := range []*data.Item{a, b, nested} {
+		assert.Equal(t, data.StatusCanceled, item.Status(), item.Title())
+	}
+	assert.True(t, nested.Modified().After(modified))
+	assert.True(t, w.Dirty())
+
+	assert.Equal(t, data.StatusNone, root.Status())
+	assert.Equal(t, data.StatusToDo, c.Status())
+
+	// the subtree is restored in a single step
+	w.Undo()
+	for _, item := range []*data.Item{a, b, nested} {
+		assert.Equal(t, data.StatusNone, item.Status(), item.Title())
+	}
+}
+
 func TestItemSetCollapsed(t *testing.T) {
 	t.Run("SetToTrue", func(t *testing.T) {
 		t.Run("No children", func(t *testing.T) {
@@ -426,6 +593,75 @@ func TestItemSetCollapsed(t *testing.T) {
 	})
 }
 
+func TestItemSearch(t *testing.T) {
+	w, a, b, c := newTestItems()
+	root := w.Root()
+	d := w.NewItem("Other")
+
+	root.Append(a)
+	a.Append(b)
+	a.SetCollapsed(true, false)
+	root.Append(d)
+	root.Append(c)
+
+	assert.Equal(t, []*data.Item{a, b, c}, root.Search("child"))
+	assert.Equal(t, []*data.Item{b}, root.Search("dB"))
+	assert.Equal(t, []*data.Item{b}, a.Search("child"))
+	assert.Empty(t, root.Search("missing"))
+}
+
+func TestItemChildCount(t *testing.T) {
+	w, a, b, c := newTestItems()
+	root := w.Root()
+
+	assert.Equal(t, 0, root.ChildCount())
+
+	root.Append(a)
+	root.Append(b)
+	b.Append(c)
+
+	assert.Equal(t, 2, root.ChildCount())
+	assert.Equal(t, 0, a.ChildCount())
+	assert.Equal(t, 1, b.ChildCount())
+}
+
+func TestItemToDoStats(t *testing.T) {
+	w, a, b, c := newTestItems()
+	root := w.Root()
+	nested := w.NewItem("Nested")
+
+	// three levels below the root: a > b, c > nested
+	root.Append(a)
+	a.Append(b)
+	a.Append(c)
+	c.Append(nested)
+
+	b.SetStatus(data.StatusDone)
+	c.SetStatus(data.StatusToDo)
+	nested.SetStatus(data.StatusCanceled)
+
+	completed, total := a.ToDoStats()
+	assert.Equal(t, 1, completed)
+	assert.Equal(t, 2, total)
+
+	completed, total = a.ToDoStatsDeep()
+	assert.Equal(t, 2, completed)
+	assert.Equal(t, 3, total)
+
+	// the item itself is not counted
+	completed, total = root.ToDoStats()
+	assert.Equal(t, 0, completed)
+	assert.Equal(t, 0, total)
+
+	completed, total = root.ToDoStatsDeep()
+	assert.Equal(t, 2, completed)
+	assert.Equal(t, 3, total)
+
+	completed, total = nested.ToDoStatsDeep()
+	assert.Equal(t, 0, completed)
+	assert.Equal(t, 0, total)
+}
+
 func TestItemDisplayChildren(t *testing.T) {
 	t.Run("EmptyParent", func(t *testing.T) {
 		w, _, _, _ := newTestItems()
@@ -467,6 +703,28 @@ func TestItemDisplayChildren(t *testing.T) {
 	})
 }
 
+func TestItemFilteredChildren(t *testing.T) {
+	w, a, b, c := newTestItems()
+	root := w.Root()
+	d := w.NewItem("ChildD")
+
+	root.Append(a)
+	a.Append(b)
+	root.Append(c)
+	c.Append(d)
+
+	a.SetStatus(data.StatusCanceled)
+
+	children := root.FilteredChildren(func(i *data.Item) bool {
+		return i.Status() == data.StatusCanceled
+	})
+	require.Len(t, children, 2)
+	assert.Same(t, c, children[0])
+	assert.Same(t, d, children[1])
+
+	assert.Equal(t, root.DisplayedChildren(), root.FilteredChildren(nil))
+}
+
 func TestItemPrevRow(t *testing.T) {
 	t.Run("No previous sibling", func(t *testing.T) {
 		t.Run("Parent is root", func(t *testing.T) {
@@ -596,6 +854,373 @@ func TestItemNextRow(t *testing.T) {
 	})
 }
 
+func TestItemCompleteWithAncestors(t *testing.T) {
+	newChain := func() (*data.Workspace, *data.Item, *data.Item, *data.Item, *data.Item) {
+		w, a, b, c := newTestItems()
+		d := w.NewItem("ChildD")
+
+		w.Root().Append(a)
+		a.Append(b)
+		b.Append(c)
+		c.Append(d)
+
+		a.SetStatus(data.StatusToDo)
+		c.SetStatus(data.StatusWaiting)
+		d.SetStatus(data.StatusToDo)
+
+		return w, a, b, c, d
+	}
+
+	t.Run("StatusedOnly", func(t *testing.T) {
+		w, a, b, c, d := newChain()
+
+		d.CompleteWithAncestors(w.Root(), false)
+
+		assert.Equal(t, data.StatusDone, d.Status())
+		assert.Equal(t, data.StatusDone, c.Status())
+		assert.Equal(t, data.StatusNone, b.Status())
+		assert.Equal(t, data.StatusDone, a.Status())
+		assert.Equal(t, data.StatusNone, w.Root().Status())
+	})
+
+	t.Run("All", func(t *testing.T) {
+		w, a, b, c, d := newChain()
+
+		d.CompleteWithAncestors(w.Root(), true)
+
+		for _, item := range []*data.Item{a, b, c, d} {
+			assert.Equal(t, data.StatusDone, item.Status(), item.Title())
+		}
+		assert.Equal(t, data.StatusNone, w.Root().Status())
+	})
+
+	t.Run("StopsAtViewRoot", func(t *testing.T) {
+		_, a, b, c, d := newChain()
+
+		d.CompleteWithAncestors(b, true)
+
+		assert.Equal(t, data.StatusDone, d.Status())
+		assert.Equal(t, data.StatusDone, c.Status())
+		assert.Equal(t, data.StatusNone, b.Status())
+		assert.Equal(t, data.StatusToDo, a.Status())
+	})
+}
+
+func TestItemAutoComplete(t *testing.T) {
+	// root > a > b, c > nested, with a and c having a status
+	newFixture := func() (*data.Workspace, *data.Item, *data.Item, *data.Item, *data.Item) {
+		w, a, b, c := newTestItems()
+		nested := w.NewItem("Nested")
+		w.Root().Append(a)
+		a.Append(b)
+		a.Append(c)
+		c.Append(nested)
+
+		a.SetStatus(data.StatusToDo)
+		b.SetStatus(data.StatusDone)
+		c.SetStatus(data.StatusWaiting)
+		nested.SetStatus(data.StatusToDo)
+		w.SetAutoComplete(true)
+
+		return w, a, b, c, nested
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		w, a, _, c, nested := newFixture()
+		w.SetAutoComplete(false)
+
+		nested.SetStatus(data.StatusDone)
+		assert.Equal(t, data.StatusWaiting, c.Status())
+		assert.Equal(t, data.StatusToDo, a.Status())
+	})
+
+	t.Run("Completion", func(t *testing.T) {
+		w, a, _, c, nested := newFixture()
+
+		nested.SetStatus(data.StatusCanceled)
+		assert.Equal(t, data.StatusDone, c.Status())
+		assert.Equal(t, data.StatusDone, a.Status())
+
+		// the cascade is a single undo step
+		w.Undo()
+		assert.Equal(t, data.StatusToDo, nested.Status())
+		assert.Equal(t, data.StatusWaiting, c.Status())
+		assert.Equal(t, data.StatusToDo, a.Status())
+	})
+
+	t.Run("Reopening", func(t *testing.T) {
+		_, a, b, c, nested := newFixture()
+		nested.SetStatus(data.StatusDone)
+		require.Equal(t, data.StatusDone, a.Status())
+
+		nested.SetStatus(data.StatusToDo)
+		assert.Equal(t, data.StatusToDo, c.Status())
+		assert.Equal(t, data.StatusToDo, a.Status())
+
+		// a canceled parent stays canceled
+		a.SetStatus(data.StatusCanceled)
+		b.SetStatus(data.StatusToDo)
+		assert.Equal(t, data.StatusCanceled, a.Status())
+	})
+
+	t.Run("NoStatus", func(t *testing.T) {
+		w, a, b, c, nested := newFixture()
+		c.SetStatus(data.StatusNone)
+		assert.Equal(t, data.StatusDone, a.Status())
+
+		// the parent without a status stops the walk up
+		nested.SetStatus(data.StatusDone)
+		assert.Equal(t, data.StatusNone, c.Status())
+
+		// clearing the last child status does not complete the parent
+		a.SetStatus(data.StatusToDo)
+		b.SetStatus(data.StatusNone)
+		assert.Equal(t, data.StatusToDo, a.Status())
+		assert.Equal(t, data.StatusNone, w.Root().Status())
+	})
+}
+
+func TestItemFindOrCreateChild(t *testing.T) {
+	t.Run("Existing", func(t *testing.T) {
+		w, a, b, _ := newTestItems()
+		root := w.Root()
+
+		root.Append(a)
+		root.Append(b)
+
+		item, created := root.FindOrCreateChild("ChildB")
+		assert.False(t, created)
+		assert.Same(t, b, item)
+		assertChildrenOrder(t, root, a, b)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		w, a, _, _ := newTestItems()
+		root := w.Root()
+
+		root.Append(a)
+
+		item, created := root.FindOrCreateChild("Scratch")
+		assert.True(t, created)
+		assert.Equal(t, "Scratch", item.Title())
+		assertChildrenOrder(t, root, a, item)
+
+		again, created := root.FindOrCreateChild("Scratch")
+		assert.False(t, created)
+		assert.Same(t, item, again)
+		assertChildrenOrder(t, root, a, item)
+	})
+}
+
+func TestItemSortChildren(t *testing.T) {
+	byTitle := func(a, b *data.Item) bool {
+		return a.Title() < b.Title()
+	}
+
+	t.Run("Sorting", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+		d := w.NewItem("ChildD")
+
+		root.Append(c)
+		root.Append(a)
+		root.Append(d)
+		root.Append(b)
+		a.Append(d)
+		a.Append(w.NewItem("Z"))
+		a.Append(w.NewItem("A"))
+
+		root.SortChildren(byTitle)
+		assertChildrenOrder(t, root, a, b, c)
+
+		// the grandchildren keep their order
+		assert.Equal(t, "ChildD", a.Head().Title())
+		assert.Equal(t, "A", a.Tail().Title())
+
+		w.Undo()
+		assertChildrenOrder(t, root, c, a, b)
+	})
+
+	t.Run("Stable", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+
+		root.Append(c)
+		root.Append(b)
+		root.Append(a)
+
+		root.SortChildren(func(x, y *data.Item) bool {
+			return x == c && y != c
+		})
+		assertChildrenOrder(t, root, c, b, a)
+
+		root.SortChildren(func(x, y *data.Item) bool {
+			return y == c && x != c
+		})
+		assertChildrenOrder(t, root, b, a, c)
+	})
+
+	t.Run("TailInPlace", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+
+		root.Append(b)
+		root.Append(c)
+		root.Append(a)
+
+		root.SortChildren(byTitle)
+		assertChildrenOrder(t, root, a, b, c)
+	})
+
+	t.Run("FewChildren", func(t *testing.T) {
+		w, a, _, _ := newTestItems()
+		root := w.Root()
+
+		root.SortChildren(byTitle)
+		assertChildrenListEmpty(t, root)
+
+		root.Append(a)
+		root.SortChildren(byTitle)
+		assertChildrenOrder(t, root, a)
+		assert.Nil(t, a.Next())
+	})
+}
+
+func TestItemClone(t *testing.T) {
+	w, a, b, c := newTestItems()
+
+	a.Append(b)
+	b.Append(c)
+	b.SetStatus(data.StatusToDo)
+	b.SetCollapsed(true, false)
+	b.AddTag("work")
+
+	clone := a.Clone(w)
+	require.Nil(t, clone.Parent())
+
+	cloneB := clone.Head()
+	require.NotNil(t, cloneB)
+	assert.NotEqual(t, b.ID(), cloneB.ID())
+	assert.Equal(t, "ChildB", cloneB.Title())
+	assert.Equal(t, data.StatusToDo, cloneB.Status())
+	assert.True(t, cloneB.Collapsed())
+	assert.Equal(t, []string{"work"}, cloneB.Tags())
+	assert.Equal(t, "ChildC", cloneB.Head().Title())
+
+	cloneB.SetTitle("Changed")
+	cloneB.SetStatus(data.StatusDone)
+	cloneB.AddTag("home")
+	cloneB.Head().Detach()
+
+	assert.Equal(t, "ChildB", b.Title())
+	assert.Equal(t, data.StatusToDo, b.Status())
+	assert.Equal(t, []string{"work"}, b.Tags())
+	assertChildrenOrder(t, b, c)
+}
+
+func TestItemCloneShape(t *testing.T) {
+	w, a, b, c := newTestItems()
+	root := w.Root()
+	d := w.NewItem("ChildD")
+
+	root.Append(a)
+	a.Append(b)
+	a.Append(c)
+	b.Append(d)
+
+	clone := a.Clone(w)
+
+	// collect the items of both trees, checking the clone has the same
+	// shape and titles along the way
+	seen := make(map[*data.Item]bool)
+	var walk func(orig, dup *data.Item)
+	walk = func(orig, dup *data.Item) {
+		seen[orig] = true
+		seen[dup] = true
+		assert.Equal(t, orig.Title(), dup.Title())
+		assert.Equal(t, orig.ChildCount(), dup.ChildCount())
+
+		o, d := orig.Head(), dup.Head()
+		for o != nil && d != nil {
+			assert.Same(t, dup, d.Parent())
+			walk(o, d)
+			o, d = o.Next(), d.Next()
+		}
+		assert.Nil(t, o)
+		assert.Nil(t, d)
+	}
+	walk(a, clone)
+
+	assert.Len(t, seen, 8)
+
+	c.Append(clone)
+	assertChildrenOrder(t, c, clone)
+	assertChildrenOrder(t, root, a)
+	assertChildrenOrder(t, a, b, c)
+	assertChildrenOrder(t, b, d)
+}
+
+func TestItemReflow(t *testing.T) {
+	t.Run("SingleParagraph", func(t *testing.T) {
+		w, a, _, _ := newTestItems()
+		root := w.Root()
+
+		root.Append(a)
+
+		assert.Nil(t, a.Reflow())
+		assert.Equal(t, "ChildA", a.Title())
+		assertChildrenListEmpty(t, a)
+	})
+
+	t.Run("MultipleParagraphs", func(t *testing.T) {
+		w, a, b, _ := newTestItems()
+		root := w.Root()
+
+		root.Append(a)
+		a.Append(b)
+		a.SetTitle("Title\nline\n\nFirst\n\n\nSecond")
+
+		children := a.Reflow()
+		require.Len(t, children, 2)
+
+		assert.Equal(t, "Title line", a.Title())
+		assert.Equal(t, "First", children[0].Title())
+		assert.Equal(t, "Second", children[1].Title())
+		assertChildrenOrder(t, a, b, children[0], children[1])
+	})
+}
+
+func TestItemMarshalXMLBytes(t *testing.T) {
+	w, a, b, c := newTestItems()
+	root := w.Root()
+
+	root.Append(a)
+	a.Append(b)
+	root.Append(c)
+
+	a.SetStatus(data.StatusToDo)
+	a.SetTitle("Fish & chips")
+
+	out, err := a.MarshalXMLBytes()
+	require.NoError(t, err)
+
+	var parsed struct {
+		XMLName xml.Name `xml:"item"`
+		Status  string   `xml:"status,attr"`
+		Title   string   `xml:"title"`
+		Items   []struct {
+			Title string `xml:"title"`
+		} `xml:"item"`
+	}
+	require.NoError(t, xml.Unmarshal(out, &parsed))
+
+	assert.Equal(t, "TODO", parsed.Status)
+	assert.Equal(t, "Fish & chips", parsed.Title)
+	require.Len(t, parsed.Items, 1)
+	assert.Equal(t, "ChildB", parsed.Items[0].Title)
+	assert.NotContains(t, string(out), "ChildC")
+}
+
 func newTestItems() (*data.Workspace, *data.Item, *data.Item, *data.Item) {
 	w := data.NewWorkspace("", "Parent")
 
@@ -640,3 +1265,207 @@ func assertItemDetached(t *testing.T, item *data.Item) {
 	assert.Nil(t, item.Prev())
 	assert.Nil(t, item.Next())
 }
+
+func TestItemNote(t *testing.T) {
+	w, items := newSavedWorkspace(t)
+	a := items[0]
+
+	out, err := a.MarshalXMLBytes()
+	require.NoError(t, err)
+	assert.NotContains(t, string(out), "<note>")
+
+	modified := a.Modified()
+	time.Sleep(time.Millisecond)
+	a.SetNote("First line\nSecond line")
+	assert.True(t, a.Modified().After(modified))
+
+	out, err = a.MarshalXMLBytes()
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "<note>First line")
+
+	w = reloadWorkspace(t, w)
+	a = w.Root().Head()
+	assert.Equal(t, "First line\nSecond line", a.Note())
+	assert.Equal(t, "", a.Next().Note())
+	assert.Equal(t, "B", a.Next().Title())
+
+	a.SetNote("")
+	w.Undo()
+	assert.Equal(t, "First line\nSecond line", a.Note())
+}
+
+func TestItemCompleted(t *testing.T) {
+	t.Run("Transitions", func(t *testing.T) {
+		w, a, _, _ := newTestItems()
+		w.Root().Append(a)
+		assert.True(t, a.Completed().IsZero())
+
+		a.SetStatus(data.StatusCanceled)
+		assert.True(t, a.Completed().IsZero())
+
+		before := time.Now()
+		a.SetStatus(data.StatusDone)
+		completed := a.Completed()
+		assert.False(t, completed.Before(before))
+
+		// the done item canceled keeps the time it was done at
+		a.SetStatus(data.StatusCanceled)
+		assert.Equal(t, completed, a.Completed())
+
+		a.SetStatus(data.StatusNone)
+		assert.True(t, a.Completed().IsZero())
+
+		w.Undo()
+		assert.Equal(t, completed, a.Completed())
+		w.Redo()
+		assert.True(t, a.Completed().IsZero())
+
+		a.SetStatus(data.StatusDone)
+		a.SetStatus(data.StatusToDo)
+		assert.True(t, a.Completed().IsZero())
+	})
+
+	t.Run("Persisted", func(t *testing.T) {
+		w, items := newSavedWorkspace(t)
+		items[1].SetStatus(data.StatusDone)
+		completed := items[1].Completed().Truncate(time.Second)
+
+		w = reloadWorkspace(t, w)
+		b := w.Root().Head().Next()
+		assert.True(t, completed.Equal(b.Completed()))
+		assert.True(t, w.Root().Head().Completed().IsZero())
+	})
+}
+
+func TestItemTimestamps(t *testing.T) {
+	t.Run("NewItem", func(t *testing.T) {
+		before := time.Now()
+		w, a, _, _ := newTestItems()
+		after := time.Now()
+
+		assert.False(t, a.Created().Before(before))
+		assert.False(t, a.Created().After(after))
+		assert.Equal(t, a.Created(), a.Modified())
+
+		w.Root().Append(a)
+		a.SetCollapsed(true, false)
+		assert.Equal(t, a.Created(), a.Modified())
+
+		// keep the timestamps apart on coarse clocks
+		time.Sleep(time.Millisecond)
+		a.SetTitle("Renamed")
+		assert.True(t, a.Modified().After(a.Created()))
+
+		modified := a.Modified()
+		time.Sleep(time.Millisecond)
+		a.SetStatus(data.StatusDone)
+		assert.True(t, a.Modified().After(modified))
+	})
+
+	t.Run("Persisted", func(t *testing.T) {
+		w, items := newSavedWorkspace(t)
+		items[1].SetStatus(data.StatusToDo)
+
+		created := items[1].Created().Truncate(time.Second)
+		modified := items[1].Modified().Truncate(time.Second)
+
+		w = reloadWorkspace(t, w)
+		b := w.Root().Head().Next()
+		require.NotNil(t, b)
+
+		assert.True(t, created.Equal(b.Created()))
+		assert.True(t, modified.Equal(b.Modified()))
+	})
+
+	t.Run("OldFile", func(t *testing.T) {
+		w, _ := newSavedWorkspace(t)
+
+		p := filepath.Join(w.Directory(), "workspace.xml")
+		raw, err := os.ReadFile(p)
+		require.NoError(t, err)
+
+		raw = regexp.MustCompile(` (created|modified)="[^"]*"`).ReplaceAll(raw, nil)
+		require.NoError(t, os.WriteFile(p, raw, 0600))
+
+		before := time.Now()
+		w, err = data.LoadWorkspace(w.Directory(), nil)
+		require.NoError(t, err)
+
+		a := w.Root().Head()
+		require.NotNil(t, a)
+		assert.False(t, a.Created().Before(before))
+		assert.Equal(t, a.Created(), a.Modified())
+	})
+}
+
+// benchmarkSizes are the numbers of items in the benchmark trees.
+var benchmarkSizes = []int{100, 1000, 10000}
+
+// newBalancedTree builds a tree of n expanded items, filled level by
+// level, each item having up to fanout children.
+func newBalancedTree(n, fanout int) *data.Workspace {
+	w := data.NewWorkspace("", "Root")
+
+	parents := []*data.Item{w.Root()}
+	for idx := range n {
+		item := w.NewItem(fmt.Sprintf("Item %d", idx))
+		parents[idx/fanout].Append(item)
+		parents = append(parents, item)
+	}
+
+	return w
+}
+
+// benchmarkTrees runs the benchmark for every tree size.
+func benchmarkTrees(b *testing.B, fanout int, bench func(b *testing.B, w *data.Workspace)) {
+	for _, n := range benchmarkSizes {
+		w := newBalancedTree(n, fanout)
+		b.Run(fmt.Sprintf("Items%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			bench(b, w)
+		})
+	}
+}
+
+func BenchmarkDisplayedChildren(b *testing.B) {
+	b.Run("Allocating", func(b *testing.B) {
+		benchmarkTrees(b, 5, func(b *testing.B, w *data.Workspace) {
+			for b.Loop() {
+				w.Root().DisplayedChildren()
+			}
+		})
+	})
+
+	b.Run("Reused", func(b *testing.B) {
+		benchmarkTrees(b, 5, func(b *testing.B, w *data.Workspace) {
+			var buf []*data.Item
+			for b.Loop() {
+				buf = w.Root().AppendDisplayed(buf[:0])
+			}
+		})
+	})
+}
+
+func BenchmarkRows(b *testing.B) {
+	// the binary tree is the deepest balanced one
+	b.Run("NextRow", func(b *testing.B) {
+		benchmarkTrees(b, 2, func(b *testing.B, w *data.Workspace) {
+			for b.Loop() {
+				for r := w.Root().Head(); r != nil; r = r.NextRow() {
+				}
+			}
+		})
+	})
+
+	b.Run("PrevRow", func(b *testing.B) {
+		benchmarkTrees(b, 2, func(b *testing.B, w *data.Workspace) {
+			rows := w.Root().DisplayedChildren()
+			last := rows[len(rows)-1]
+
+			for b.Loop() {
+				for r := last; r != nil; r = r.PrevRow() {
+				}
+			}
+		})
+	})
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// jsonDocument is the JSON form of the workspace. Like the workspace
+// file, it holds the whole tree and the ids of the cursor and the root.
+type jsonDocument struct {
+	Cursor uuid.UUID `json:"cursor"`
+	Root   uuid.UUID `json:"root"`
+	Item   jsonItem  `json:"item"`
+}
+
+type jsonItem struct {
+	ID        uuid.UUID  `json:"id"`
+	Title     string     `json:"title"`
+	Status    string     `json:"status"`
+	Collapsed bool       `json:"collapsed"`
+	Children  []jsonItem `json:"children"`
+}
+
+func newJSONItem(i *Item) jsonItem {
+	ji := jsonItem{
+		ID:        i.id,
+		Title:     i.title,
+		Status:    i.status.String(),
+		Collapsed: i.collapsed,
+		Children:  []jsonItem{},
+	}
+
+	for c := i.head; c != nil; c = c.next {
+		ji.Children = append(ji.Children, newJSONItem(c))
+	}
+
+	return ji
+}
+
+// ExportJSON writes the whole workspace tree as an indented JSON
+// document with the ids of the cursor and the root.
+func ExportJSON(w *Workspace, out io.Writer) error {
+	return ExportSubtreeJSON(w.realRoot, out)
+}
+
+// ExportSubtreeJSON is ExportJSON writing the subtree of root, which
+// takes the place of the real root in the document.
+func ExportSubtreeJSON(root *Item, out io.Writer) error {
+	w := root.workspace
+	doc := jsonDocument{
+		Cursor: w.cursor.id,
+		Root:   w.root.id,
+		Item:   newJSONItem(root),
+	}
+
+	e := json.NewEncoder(out)
+	e.SetIndent("", "  ")
+	return e.Encode(doc)
+}
+
+// statusNames lists the status strings accepted by ParseStatus.
+func statusNames() string {
+	names := make([]string, 0, StatusScheduled+1)
+	for s := StatusNone; s <= StatusScheduled; s++ {
+		names = append(names, s.String())
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// setJSONItem sets the fields of the item and appends its children.
+func (w *Workspace) setJSONItem(i *Item, ji jsonItem) error {
+	s, err := ParseStatus(ji.Status)
+	if err != nil {
+		return fmt.Errorf("item %s: unknown status %q, expected one of: %s", ji.ID, ji.Status, statusNames())
+	}
+
+	if ji.ID != uuid.Nil {
+		i.id = ji.ID
+	}
+
+	i.title = ji.Title
+	i.status = s
+	i.collapsed = ji.Collapsed
+
+	for _, jc := range ji.Children {
+		c := w.NewItem("")
+		if err := w.setJSONItem(c, jc); err != nil {
+			return err
+		}
+		i.appendChild(c)
+	}
+
+	return nil
+}
+
+// ImportJSON reads a document written by ExportJSON into a new
+// workspace without a directory. The items keep their ids, and the
+// cursor and the root missing from the tree fall back to the real root.
+func ImportJSON(in io.Reader) (*Workspace, error) {
+	var doc jsonDocument
+	if err := json.NewDecoder(in).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	w := NewWorkspace("", "")
+	if err := w.setJSONItem(w.realRoot, doc.Item); err != nil {
+		return nil, err
+	}
+
+	w.reindex()
+
+	w.root = w.lookupItem(doc.Root)
+	w.cursor = w.lookupItem(doc.Cursor)
+
+	return w, nil
+}
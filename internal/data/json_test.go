@@ -0,0 +1,104 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	w, a, b, c := newTestItems()
+	root := w.Root()
+
+	root.Append(a)
+	a.Append(b)
+	root.Append(c)
+
+	b.SetStatus(data.StatusWaiting)
+	a.SetCollapsed(true, false)
+	w.SetRoot(a)
+	w.SetCursor(b)
+
+	// the tree comes from the workspace file
+	xmlData, err := xml.Marshal(w)
+	require.NoError(t, err)
+	loaded := data.NewWorkspace("", "")
+	require.NoError(t, xml.Unmarshal(xmlData, loaded))
+
+	var buf bytes.Buffer
+	require.NoError(t, data.ExportJSON(loaded, &buf))
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, b.ID().String(), doc["cursor"])
+	assert.Equal(t, a.ID().String(), doc["root"])
+
+	imported, err := data.ImportJSON(&buf)
+	require.NoError(t, err)
+
+	ia := imported.Root()
+	assert.Equal(t, a.ID(), ia.ID())
+	assert.Equal(t, "ChildA", ia.Title())
+	assert.True(t, ia.Collapsed())
+
+	ib := imported.Cursor()
+	assert.Equal(t, b.ID(), ib.ID())
+	assert.Same(t, ia, ib.Parent())
+	assert.Equal(t, data.StatusWaiting, ib.Status())
+
+	realRoot := ia.Parent()
+	require.NotNil(t, realRoot)
+	assert.Equal(t, root.ID(), realRoot.ID())
+	assert.Equal(t, "Parent", realRoot.Title())
+	assert.Equal(t, c.ID(), realRoot.Tail().ID())
+	assert.Same(t, ia, realRoot.Head())
+}
+
+func TestImportJSON(t *testing.T) {
+	t.Run("UnknownStatus", func(t *testing.T) {
+		in := `{"item": {"title": "Home", "status": "NONE", "children": [{"title": "Task", "status": "LATER"}]}}`
+
+		_, err := data.ImportJSON(strings.NewReader(in))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown status "LATER"`)
+		assert.Contains(t, err.Error(), "TODO")
+	})
+
+	t.Run("MissingCursor", func(t *testing.T) {
+		in := `{"item": {"title": "Home", "status": "NONE", "children": [{"title": "Task", "status": "TODO"}]}}`
+
+		w, err := data.ImportJSON(strings.NewReader(in))
+		require.NoError(t, err)
+
+		realRoot := w.Root()
+		assert.Equal(t, "Home", realRoot.Title())
+		assert.Same(t, realRoot, w.Cursor())
+		assert.Equal(t, data.StatusToDo, realRoot.Head().Status())
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := data.ImportJSON(strings.NewReader(`{"item": [`))
+		assert.Error(t, err)
+	})
+}
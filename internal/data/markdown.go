@@ -0,0 +1,77 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// markdownCheckbox returns the GitHub task list checkbox of the status
+// followed by the status keyword, unless the checkbox alone tells it.
+func markdownCheckbox(s Status) string {
+	switch s {
+	case StatusNone:
+		return ""
+	case StatusToDo:
+		return "[ ]"
+	case StatusDone:
+		return "[x]"
+	case StatusCanceled:
+		return "[x] " + s.String()
+	default:
+		return "[ ] " + s.String()
+	}
+}
+
+// ExportMarkdown writes the descendants of root as a nested Markdown
+// bullet list, indented by two spaces per level below root. Items with
+// a status other than "None" are written as task list items, checked
+// for the completed statuses. The status keyword follows the checkbox
+// if the checkbox does not imply it, i.e. for anything but "TODO" and
+// "DONE". The collapsed state is ignored.
+func ExportMarkdown(root *Item, w io.Writer) error {
+	return exportMarkdown(root, w, 0)
+}
+
+func exportMarkdown(parent *Item, w io.Writer, level int) error {
+	for c := parent.Head(); c != nil; c = c.Next() {
+		title := c.Title()
+
+		// keep a literal bracket from being read as a checkbox
+		if strings.HasPrefix(title, "[") {
+			title = `\` + title
+		}
+
+		parts := []string{"-"}
+		if checkbox := markdownCheckbox(c.Status()); checkbox != "" {
+			parts = append(parts, checkbox)
+		}
+		if title != "" {
+			parts = append(parts, title)
+		}
+
+		if _, err := fmt.Fprintln(w, strings.Repeat(textIndent, level)+strings.Join(parts, " ")); err != nil {
+			return err
+		}
+
+		if err := exportMarkdown(c, w, level+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestExportMarkdown(t *testing.T) {
+	t.Run("NestedItems", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+
+		root.Append(a)
+		a.Append(b)
+		b.Append(c)
+		a.SetCollapsed(true, true)
+
+		var buf bytes.Buffer
+		require.NoError(t, data.ExportMarkdown(root, &buf))
+		assert.Equal(t, "- ChildA\n  - ChildB\n    - ChildC\n", buf.String())
+	})
+
+	t.Run("Statuses", func(t *testing.T) {
+		w := data.NewWorkspace("", "Root")
+		root := w.Root()
+
+		for _, s := range []data.Status{
+			data.StatusNone,
+			data.StatusToDo,
+			data.StatusDone,
+			data.StatusCanceled,
+			data.StatusWaiting,
+			data.StatusScheduled,
+		} {
+			item := w.NewItem("Task")
+			item.SetStatus(s)
+			root.Append(item)
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, data.ExportMarkdown(root, &buf))
+		assert.Equal(t, "- Task\n"+
+			"- [ ] Task\n"+
+			"- [x] Task\n"+
+			"- [x] CANC Task\n"+
+			"- [ ] WAIT Task\n"+
+			"- [ ] SCHD Task\n", buf.String())
+	})
+
+	t.Run("EmptyTitles", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+
+		root.Append(a)
+		root.Append(b)
+		root.Append(c)
+
+		a.SetTitle("")
+		b.SetTitle("")
+		b.SetStatus(data.StatusDone)
+		c.SetTitle("[x] not a checkbox")
+
+		var buf bytes.Buffer
+		require.NoError(t, data.ExportMarkdown(root, &buf))
+		assert.Equal(t, "-\n- [x]\n- \\[x] not a checkbox\n", buf.String())
+	})
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	xmlElemMark    = "mark"
+	xmlMarkAttrKey = "key"
+	xmlMarkAttrId  = "id"
+)
+
+// SetMark marks the item with the rune, replacing the item it marked
+// before.
+func (w *Workspace) SetMark(r rune, item *Item) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.marks == nil {
+		w.marks = make(map[rune]uuid.UUID)
+	}
+	w.marks[r] = item.id
+	w.dirty = true
+}
+
+// Mark returns the item marked with the rune. The mark of an item no
+// longer in the tree, e.g. deleted, is stale: it is removed and not
+// found.
+func (w *Workspace) Mark(r rune) (*Item, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	id, ok := w.marks[r]
+	if !ok {
+		return nil, false
+	}
+
+	item, ok := w.itemIndex[id]
+	if !ok || !item.inTree() {
+		delete(w.marks, r)
+		w.dirty = true
+		return nil, false
+	}
+
+	return item, true
+}
+
+// encodeMarks writes the marks ordered by their runes.
+func (w *Workspace) encodeMarks(e *xml.Encoder) error {
+	keys := make([]rune, 0, len(w.marks))
+	for r := range w.marks {
+		keys = append(keys, r)
+	}
+	slices.SortFunc(keys, cmp.Compare)
+
+	for _, r := range keys {
+		mark := xml.StartElement{
+			Name: xml.Name{Local: xmlElemMark},
+			Attr: []xml.Attr{
+				{Name: xml.Name{Local: xmlMarkAttrKey}, Value: string(r)},
+				{Name: xml.Name{Local: xmlMarkAttrId}, Value: w.marks[r].String()},
+			},
+		}
+		if err := e.EncodeToken(mark); err != nil {
+			return err
+		}
+		if err := e.EncodeToken(mark.End()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decodeMark reads a mark element.
+func (w *Workspace) decodeMark(d *xml.Decoder, start xml.StartElement) error {
+	var r rune
+	var id uuid.UUID
+
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case xmlMarkAttrKey:
+			if utf8.RuneCountInString(attr.Value) != 1 {
+				return fmt.Errorf("invalid mark key %q", attr.Value)
+			}
+			r, _ = utf8.DecodeRuneInString(attr.Value)
+		case xmlMarkAttrId:
+			var err error
+			if id, err = uuid.Parse(attr.Value); err != nil {
+				return err
+			}
+		}
+	}
+
+	if w.marks == nil {
+		w.marks = make(map[rune]uuid.UUID)
+	}
+	w.marks[r] = id
+
+	return d.Skip()
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarks(t *testing.T) {
+	t.Run("Stored", func(t *testing.T) {
+		w, items := newSavedWorkspace(t)
+		w.SetMark('a', items[1])
+		w.SetMark('é', items[2])
+		assert.True(t, w.Dirty())
+
+		w = reloadWorkspace(t, w)
+
+		b, ok := w.Mark('a')
+		require.True(t, ok)
+		assert.Equal(t, items[1].ID(), b.ID())
+
+		c, ok := w.Mark('é')
+		require.True(t, ok)
+		assert.Equal(t, items[2].ID(), c.ID())
+
+		_, ok = w.Mark('z')
+		assert.False(t, ok)
+	})
+
+	t.Run("Stale", func(t *testing.T) {
+		w, items := newSavedWorkspace(t)
+		w.SetMark('a', items[1])
+		items[1].Trash()
+
+		_, ok := w.Mark('a')
+		assert.False(t, ok)
+
+		// the mark is cleared, restoring the item does not bring it back
+		items[1].Restore()
+		_, ok = w.Mark('a')
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// migration converts the workspace file data of a storage version to
+// the next version.
+type migration func(data []byte) ([]byte, error)
+
+// migrations maps the storage versions older than the current one to
+// the migrations to the next version. They are chained, so a file of
+// any listed version is converted to the current one.
+var migrations = map[int]migration{
+	1: migrateV1,
+}
+
+// errMissingRootElement is returned for the workspace file data having
+// no root element, such as an empty file.
+var errMissingRootElement = errors.New("workspace file has no root element")
+
+// storedVersion returns the storage version of the workspace file data.
+// The files written before the version was stored are version 1.
+func storedVersion(data []byte) (int, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return 0, errMissingRootElement
+		} else if err != nil {
+			return 0, err
+		}
+
+		se, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		for _, attr := range se.Attr {
+			if attr.Name.Local == xmlWorkspaceAttrVersion {
+				v, err := strconv.Atoi(attr.Value)
+				if err != nil {
+					return 0, fmt.Errorf("failed to parse storage version: %w", err)
+				}
+				return v, nil
+			}
+		}
+
+		return 1, nil
+	}
+}
+
+// migrate converts the workspace file data to the current storage
+// version. It reports whether the data was converted.
+func migrate(data []byte) ([]byte, bool, error) {
+	v, err := storedVersion(data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if v == storageVersion {
+		return data, false, nil
+	}
+
+	for ; v < storageVersion; v++ {
+		m, ok := migrations[v]
+		if !ok {
+			break
+		}
+
+		if data, err = m(data); err != nil {
+			return nil, false, fmt.Errorf("failed to migrate storage version %d: %w", v, err)
+		}
+	}
+
+	if v != storageVersion {
+		return nil, false, fmt.Errorf("unsupported storage version %d", v)
+	}
+
+	return data, true, nil
+}
+
+// rewriteElements copies the XML data, passing every start element to
+// rewrite first.
+func rewriteElements(data []byte, rewrite func(se *xml.StartElement)) ([]byte, error) {
+	var buf bytes.Buffer
+
+	d := xml.NewDecoder(bytes.NewReader(data))
+	e := xml.NewEncoder(&buf)
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if se, ok := tok.(xml.StartElement); ok {
+			rewrite(&se)
+			tok = se
+		}
+
+		if err := e.EncodeToken(tok); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := e.Flush(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// setAttr sets the value of the element attribute, adding it if absent.
+func setAttr(se *xml.StartElement, name, value string) {
+	for i := range se.Attr {
+		if se.Attr[i].Name.Local == name {
+			se.Attr[i].Value = value
+			return
+		}
+	}
+
+	se.Attr = append(se.Attr, xml.Attr{Name: xml.Name{Local: name}, Value: value})
+}
+
+// hasAttr reports whether the element has the attribute.
+func hasAttr(se *xml.StartElement, name string) bool {
+	for _, attr := range se.Attr {
+		if attr.Name.Local == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// migrateV1 converts the version 1 data, which has no ids on some of
+// the items, by assigning the new ones.
+func migrateV1(data []byte) ([]byte, error) {
+	return rewriteElements(data, func(se *xml.StartElement) {
+		switch se.Name.Local {
+		case xmlElemWorkspace:
+			setAttr(se, xmlWorkspaceAttrVersion, "2")
+		case xmlElemItem:
+			if !hasAttr(se, xmlItemAttrId) {
+				setAttr(se, xmlItemAttrId, uuid.NewString())
+			}
+		}
+	})
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+const opmlVersion = "2.0"
+
+type opmlDocument struct {
+	XMLName xml.Name      `xml:"opml"`
+	Version string        `xml:"version,attr"`
+	Title   string        `xml:"head>title"`
+	Body    []opmlOutline `xml:"body>outline"`
+}
+
+// opmlOutline is an OPML outline element. The item status is kept in
+// the custom "_status" attribute.
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Status   string        `xml:"_status,attr,omitempty"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+func newOPMLOutlines(parent *Item) []opmlOutline {
+	var outlines []opmlOutline
+	for c := parent.head; c != nil; c = c.next {
+		o := opmlOutline{
+			Text:     c.title,
+			Outlines: newOPMLOutlines(c),
+		}
+		if c.status != StatusNone {
+			o.Status = c.status.String()
+		}
+		outlines = append(outlines, o)
+	}
+
+	return outlines
+}
+
+// ExportOPML writes the whole workspace tree as an OPML 2.0 document.
+// The real root title becomes the document title.
+func ExportOPML(w *Workspace, out io.Writer) error {
+	return ExportSubtreeOPML(w.realRoot, out)
+}
+
+// ExportSubtreeOPML writes the descendants of root as an OPML 2.0
+// document titled after root.
+func ExportSubtreeOPML(root *Item, out io.Writer) error {
+	doc := opmlDocument{
+		Version: opmlVersion,
+		Title:   root.title,
+		Body:    newOPMLOutlines(root),
+	}
+
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+
+	e := xml.NewEncoder(out)
+	e.Indent("", "  ")
+	if err := e.Encode(doc); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(out, "\n")
+	return err
+}
+
+func (w *Workspace) appendOPMLOutlines(parent *Item, outlines []opmlOutline) {
+	for _, o := range outlines {
+		item := w.NewItem(o.Text)
+
+		// statuses unknown to oli are dropped
+		if s, err := ParseStatus(o.Status); err == nil {
+			item.status = s
+		}
+
+		parent.appendChild(item)
+		w.appendOPMLOutlines(item, o.Outlines)
+	}
+}
+
+// ImportOPML reads an OPML document into a new workspace without a
+// directory. The named HTML entities are accepted in the attributes,
+// and the outline elements without text become items with empty titles.
+func ImportOPML(in io.Reader) (*Workspace, error) {
+	var doc opmlDocument
+
+	d := xml.NewDecoder(in)
+	d.Entity = xml.HTMLEntity
+	if err := d.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	w := NewWorkspace("", doc.Title)
+	w.appendOPMLOutlines(w.realRoot, doc.Body)
+
+	if w.realRoot.head == nil {
+		w.realRoot.appendChild(w.NewItem(""))
+	}
+	w.cursor = w.realRoot.head
+	w.reindex()
+
+	return w, nil
+}
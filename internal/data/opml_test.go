@@ -0,0 +1,101 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestOPMLRoundTrip(t *testing.T) {
+	w, a, b, c := newTestItems()
+	root := w.Root()
+
+	root.Append(a)
+	a.Append(b)
+	root.Append(c)
+
+	a.SetTitle(`Tom & "Jerry" <3`)
+	b.SetStatus(data.StatusWaiting)
+	c.SetTitle("")
+
+	var buf bytes.Buffer
+	require.NoError(t, data.ExportOPML(w, &buf))
+	assert.Contains(t, buf.String(), `<opml version="2.0">`)
+
+	imported, err := data.ImportOPML(&buf)
+	require.NoError(t, err)
+
+	importedRoot := imported.Root()
+	assert.Equal(t, "Parent", importedRoot.Title())
+
+	ia := importedRoot.Head()
+	require.NotNil(t, ia)
+	assert.Equal(t, `Tom & "Jerry" <3`, ia.Title())
+	assert.NotEqual(t, a.ID(), ia.ID())
+
+	ib := ia.Head()
+	require.NotNil(t, ib)
+	assert.Equal(t, "ChildB", ib.Title())
+	assert.Equal(t, data.StatusWaiting, ib.Status())
+	assert.Nil(t, ib.Next())
+
+	ic := ia.Next()
+	require.NotNil(t, ic)
+	assert.Equal(t, "", ic.Title())
+	assert.Equal(t, data.StatusNone, ic.Status())
+	assert.Same(t, ic, importedRoot.Tail())
+
+	assert.Same(t, ia, imported.Cursor())
+}
+
+func TestImportOPML(t *testing.T) {
+	t.Run("HTMLEntities", func(t *testing.T) {
+		in := `<opml version="2.0"><head><title>Notes</title></head><body>` +
+			`<outline text="Caf&eacute;&nbsp;list &amp; more" _status="BOGUS"/>` +
+			`<outline/>` +
+			`</body></opml>`
+
+		w, err := data.ImportOPML(strings.NewReader(in))
+		require.NoError(t, err)
+
+		first := w.Root().Head()
+		require.NotNil(t, first)
+		assert.Equal(t, "Café list & more", first.Title())
+		assert.Equal(t, data.StatusNone, first.Status())
+
+		require.NotNil(t, first.Next())
+		assert.Equal(t, "", first.Next().Title())
+	})
+
+	t.Run("EmptyBody", func(t *testing.T) {
+		w, err := data.ImportOPML(strings.NewReader(`<opml version="2.0"><head/><body/></opml>`))
+		require.NoError(t, err)
+
+		require.NotNil(t, w.Root().Head())
+		assert.Same(t, w.Root().Head(), w.Cursor())
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := data.ImportOPML(strings.NewReader(`<opml><body>`))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// orgDateLayout is the layout of the org-mode active timestamps, which
+// are put in angle brackets.
+const orgDateLayout = "2006-01-02 Mon"
+
+// orgStatus is the org-mode form of a status.
+type orgStatus struct {
+	// TODO keyword put after the heading stars
+	keyword string
+
+	// planning keyword the due date is written with, if any
+	planning string
+}
+
+// orgStatuses maps the statuses to their org-mode form. The keywords
+// other than TODO and DONE need a matching #+TODO line or the
+// org-todo-keywords setting to be recognized by Emacs.
+var orgStatuses = [...]orgStatus{
+	StatusNone:      {},
+	StatusToDo:      {keyword: "TODO"},
+	StatusDone:      {keyword: "DONE"},
+	StatusCanceled:  {keyword: "CANCELED"},
+	StatusWaiting:   {keyword: "WAIT", planning: "DEADLINE"},
+	StatusScheduled: {keyword: "SCHEDULED", planning: "SCHEDULED"},
+}
+
+// ExportOrg writes the descendants of root as org-mode headings, with
+// one star for the children of root and one more per level below them.
+// The statuses become the TODO keywords, the notes become the heading
+// body text, and the due dates of the scheduled and waiting items
+// become the SCHEDULED and DEADLINE timestamps. The collapsed state is
+// ignored.
+func ExportOrg(root *Item, w io.Writer) error {
+	return exportOrg(root, w, 1)
+}
+
+func exportOrg(parent *Item, w io.Writer, level int) error {
+	for c := parent.Head(); c != nil; c = c.Next() {
+		org := orgStatuses[c.Status()]
+
+		parts := []string{strings.Repeat("*", level)}
+		if org.keyword != "" {
+			parts = append(parts, org.keyword)
+		}
+		if title := c.Title(); title != "" {
+			parts = append(parts, title)
+		}
+
+		if _, err := fmt.Fprintln(w, strings.Join(parts, " ")); err != nil {
+			return err
+		}
+
+		if due := c.Due(); org.planning != "" && !due.IsZero() {
+			if _, err := fmt.Fprintf(w, "%s: <%s>\n", org.planning, due.Format(orgDateLayout)); err != nil {
+				return err
+			}
+		}
+
+		if note := c.Note(); note != "" {
+			for _, line := range strings.Split(note, "\n") {
+				// keep a line from being read as a heading
+				if strings.HasPrefix(line, "*") {
+					line = " " + line
+				}
+
+				if _, err := fmt.Fprintln(w, line); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := exportOrg(c, w, level+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
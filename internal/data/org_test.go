@@ -0,0 +1,106 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestExportOrg(t *testing.T) {
+	t.Run("NestedItems", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+
+		root.Append(a)
+		a.Append(b)
+		b.Append(c)
+		root.Append(w.NewItem(""))
+		a.SetCollapsed(true, true)
+
+		var buf bytes.Buffer
+		require.NoError(t, data.ExportOrg(root, &buf))
+		assert.Equal(t, "* ChildA\n** ChildB\n*** ChildC\n*\n", buf.String())
+	})
+
+	t.Run("Statuses", func(t *testing.T) {
+		w := data.NewWorkspace("", "Root")
+		root := w.Root()
+
+		for _, s := range []data.Status{
+			data.StatusNone,
+			data.StatusToDo,
+			data.StatusDone,
+			data.StatusCanceled,
+			data.StatusWaiting,
+			data.StatusScheduled,
+		} {
+			item := w.NewItem("Task")
+			item.SetStatus(s)
+			root.Append(item)
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, data.ExportOrg(root, &buf))
+		assert.Equal(t, "* Task\n"+
+			"* TODO Task\n"+
+			"* DONE Task\n"+
+			"* CANCELED Task\n"+
+			"* WAIT Task\n"+
+			"* SCHEDULED Task\n", buf.String())
+	})
+
+	t.Run("DueDates", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+
+		root.Append(a)
+		root.Append(b)
+		root.Append(c)
+
+		due := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.Local)
+		for _, item := range []*data.Item{a, b, c} {
+			item.SetDue(due)
+		}
+		a.SetStatus(data.StatusScheduled)
+		b.SetStatus(data.StatusWaiting)
+		c.SetStatus(data.StatusToDo)
+
+		var buf bytes.Buffer
+		require.NoError(t, data.ExportOrg(root, &buf))
+		assert.Equal(t, "* SCHEDULED ChildA\nSCHEDULED: <2025-03-14 Fri>\n"+
+			"* WAIT ChildB\nDEADLINE: <2025-03-14 Fri>\n"+
+			"* TODO ChildC\n", buf.String())
+	})
+
+	t.Run("Notes", func(t *testing.T) {
+		w, a, b, _ := newTestItems()
+		root := w.Root()
+
+		root.Append(a)
+		a.Append(b)
+		a.SetNote("first line\n* not a heading")
+
+		var buf bytes.Buffer
+		require.NoError(t, data.ExportOrg(root, &buf))
+		assert.Equal(t, "* ChildA\nfirst line\n * not a heading\n** ChildB\n", buf.String())
+	})
+}
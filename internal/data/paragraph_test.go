@@ -0,0 +1,44 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestSplitParagraphs(t *testing.T) {
+	t.Run("SingleLine", func(t *testing.T) {
+		assert.Equal(t, []string{"foo"}, data.SplitParagraphs("foo"))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Empty(t, data.SplitParagraphs(""))
+		assert.Empty(t, data.SplitParagraphs("\n  \n"))
+	})
+
+	t.Run("MultipleParagraphs", func(t *testing.T) {
+		s := "first line\nsecond line\n\nsecond paragraph\n \n\n\tthird paragraph  \n"
+
+		assert.Equal(t, []string{
+			"first line second line",
+			"second paragraph",
+			"third paragraph",
+		}, data.SplitParagraphs(s))
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import "fmt"
+
+type Priority int
+
+const (
+	PriorityNone Priority = iota
+	PriorityA
+	PriorityB
+	PriorityC
+)
+
+func ParsePriority(s string) (Priority, error) {
+	switch s {
+	case "NONE":
+		return PriorityNone, nil
+	case "A":
+		return PriorityA, nil
+	case "B":
+		return PriorityB, nil
+	case "C":
+		return PriorityC, nil
+	default:
+		return -1, fmt.Errorf("unexpected priority string: %s", s)
+	}
+}
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityNone:
+		return "NONE"
+	case PriorityA:
+		return "A"
+	case PriorityB:
+		return "B"
+	case PriorityC:
+		return "C"
+	default:
+		panic("unexpected priority value")
+	}
+}
+
+// Priority returns the item priority.
+func (i *Item) Priority() Priority {
+	return i.priority
+}
+
+// SetPriority updates the item priority and records the change for
+// undo.
+func (i *Item) SetPriority(p Priority) {
+	old := i.priority
+	if old == p {
+		return
+	}
+
+	i.priority = p
+	i.workspace.recordEdit(i, func() { i.priority = old }, func() { i.priority = p })
+}
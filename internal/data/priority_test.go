@@ -0,0 +1,64 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestParsePriority(t *testing.T) {
+	for _, p := range []data.Priority{
+		data.PriorityNone,
+		data.PriorityA,
+		data.PriorityB,
+		data.PriorityC,
+	} {
+		parsed, err := data.ParsePriority(p.String())
+		require.NoError(t, err)
+		assert.Equal(t, p, parsed)
+	}
+
+	_, err := data.ParsePriority("D")
+	assert.Error(t, err)
+}
+
+func TestItemPriority(t *testing.T) {
+	w, items := newSavedWorkspace(t)
+	a := items[0]
+
+	out, err := a.MarshalXMLBytes()
+	require.NoError(t, err)
+	assert.NotContains(t, string(out), "priority")
+
+	a.SetPriority(data.PriorityB)
+	assert.Equal(t, data.PriorityB, a.Priority())
+
+	out, err = a.MarshalXMLBytes()
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `priority="B"`)
+
+	w = reloadWorkspace(t, w)
+	assert.Equal(t, data.PriorityB, w.Root().Head().Priority())
+
+	a = w.Root().Head()
+	a.SetPriority(data.PriorityA)
+	w.Undo()
+	assert.Equal(t, data.PriorityB, a.Priority())
+}
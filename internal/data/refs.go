@@ -0,0 +1,78 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Refs returns the ids of the items the item refers to, in the order
+// the references were added.
+func (i *Item) Refs() []uuid.UUID {
+	return i.refs
+}
+
+// AddRef adds a reference to the item with the id. Adding a reference
+// the item already has, or one to the item itself, does nothing.
+func (i *Item) AddRef(id uuid.UUID) {
+	if id == i.id || slices.Contains(i.refs, id) {
+		return
+	}
+
+	i.setRefs(append(slices.Clone(i.refs), id))
+}
+
+// RemoveRef removes the reference to the item with the id, if any.
+func (i *Item) RemoveRef(id uuid.UUID) {
+	if idx := slices.Index(i.refs, id); idx >= 0 {
+		i.setRefs(slices.Delete(slices.Clone(i.refs), idx, idx+1))
+	}
+}
+
+// setRefs replaces the item references and records the change for
+// undo. Like the tags, the slices are never modified in place.
+func (i *Item) setRefs(refs []uuid.UUID) {
+	old := i.refs
+	i.refs = refs
+	i.workspace.recordEdit(i, func() { i.refs = old }, func() { i.refs = refs })
+}
+
+// RefTargets returns the referenced items which are in the workspace
+// tree, skipping the dangling references.
+func (i *Item) RefTargets() []*Item {
+	var targets []*Item
+	for _, id := range i.refs {
+		if t, ok := i.workspace.GetByID(id); ok && t.inTree() {
+			targets = append(targets, t)
+		}
+	}
+
+	return targets
+}
+
+// DanglingRefs returns the ids of the references to the items which
+// were deleted or never loaded.
+func (i *Item) DanglingRefs() []uuid.UUID {
+	var dangling []uuid.UUID
+	for _, id := range i.refs {
+		if t, ok := i.workspace.GetByID(id); !ok || !t.inTree() {
+			dangling = append(dangling, id)
+		}
+	}
+
+	return dangling
+}
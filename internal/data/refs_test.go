@@ -0,0 +1,81 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestItemRefs(t *testing.T) {
+	t.Run("AddAndRemove", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		w.Root().Append(a)
+		w.Root().Append(b)
+		w.Root().Append(c)
+
+		a.AddRef(c.ID())
+		a.AddRef(b.ID())
+		a.AddRef(c.ID())
+		a.AddRef(a.ID())
+		assert.Equal(t, []uuid.UUID{c.ID(), b.ID()}, a.Refs())
+		assert.Equal(t, []*data.Item{c, b}, a.RefTargets())
+
+		a.RemoveRef(c.ID())
+		assert.Equal(t, []uuid.UUID{b.ID()}, a.Refs())
+
+		w.Undo()
+		assert.Equal(t, []uuid.UUID{c.ID(), b.ID()}, a.Refs())
+	})
+
+	t.Run("Dangling", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		w.Root().Append(a)
+		w.Root().Append(b)
+		b.Append(c)
+
+		missing := uuid.New()
+		a.AddRef(missing)
+		a.AddRef(c.ID())
+		a.AddRef(b.ID())
+
+		// the subtree of a deleted item is gone too
+		b.Detach()
+		assert.Empty(t, a.RefTargets())
+		assert.Equal(t, []uuid.UUID{missing, c.ID(), b.ID()}, a.DanglingRefs())
+
+		w.Undo()
+		assert.Equal(t, []*data.Item{c, b}, a.RefTargets())
+		assert.Equal(t, []uuid.UUID{missing}, a.DanglingRefs())
+	})
+
+	t.Run("SaveAndLoad", func(t *testing.T) {
+		w, items := newSavedWorkspace(t)
+		items[0].AddRef(items[2].ID())
+		items[0].AddRef(items[1].ID())
+
+		w = reloadWorkspace(t, w)
+		first := w.Root().Head()
+		require.Len(t, first.RefTargets(), 2)
+		assert.Equal(t, "C", first.RefTargets()[0].Title())
+		assert.Equal(t, "B", first.RefTargets()[1].Title())
+		assert.Empty(t, first.Next().Refs())
+	})
+}
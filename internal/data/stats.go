@@ -0,0 +1,53 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+// Stats is the summary of the workspace tree.
+type Stats struct {
+	// number of the items, except for the real root
+	Items int
+
+	// number of the items in every status
+	Statuses map[Status]int
+
+	// depth of the deepest item, the top-level items having depth 1
+	MaxDepth int
+
+	// number of the collapsed items with children
+	Collapsed int
+}
+
+// Stats counts the items of the whole tree, regardless of the view
+// root.
+func (w *Workspace) Stats() Stats {
+	s := Stats{Statuses: make(map[Status]int)}
+
+	var walk func(parent *Item, depth int)
+	walk = func(parent *Item, depth int) {
+		for c := parent.head; c != nil; c = c.next {
+			s.Items++
+			s.Statuses[c.status]++
+			s.MaxDepth = max(s.MaxDepth, depth)
+			if c.collapsed && c.head != nil {
+				s.Collapsed++
+			}
+
+			walk(c, depth+1)
+		}
+	}
+	walk(w.realRoot, 1)
+
+	return s
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestWorkspaceStats(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		w := data.NewWorkspace(t.TempDir(), "Root")
+
+		s := w.Stats()
+		assert.Zero(t, s.Items)
+		assert.Zero(t, s.MaxDepth)
+		assert.Empty(t, s.Statuses)
+	})
+
+	t.Run("Tree", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		d := w.NewItem("D")
+		e := w.NewItem("E")
+		root := w.Root()
+
+		// Root
+		//   A (collapsed)
+		//     B
+		//       D
+		//   C
+		//     E (collapsed leaf)
+		root.Append(a)
+		root.Append(c)
+		a.Append(b)
+		b.Append(d)
+		c.Append(e)
+		a.SetCollapsed(true, false)
+		b.SetStatus(data.StatusToDo)
+		d.SetStatus(data.StatusDone)
+		e.SetStatus(data.StatusDone)
+		e.SetCollapsed(true, false)
+
+		// the view root does not matter
+		w.SetRoot(b)
+
+		s := w.Stats()
+		assert.Equal(t, 5, s.Items)
+		assert.Equal(t, 3, s.MaxDepth)
+		assert.Equal(t, 1, s.Collapsed)
+		assert.Equal(t, map[data.Status]int{
+			data.StatusNone: 2,
+			data.StatusToDo: 1,
+			data.StatusDone: 2,
+		}, s.Statuses)
+	})
+}
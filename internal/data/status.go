@@ -14,7 +14,10 @@
 
 package data
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+)
 
 type Status int
 
@@ -64,3 +67,27 @@ func (s Status) String() string {
 		panic("unexpected status value")
 	}
 }
+
+// statusCycle is the order Next and Prev cycle the statuses in. The
+// statuses missing from it are followed by its first one either way.
+var statusCycle = []Status{StatusNone, StatusToDo, StatusDone}
+
+// Next returns the status following s in the cycle, wrapping around.
+func (s Status) Next() Status {
+	return s.cycle(1)
+}
+
+// Prev returns the status preceding s in the cycle, wrapping around.
+func (s Status) Prev() Status {
+	return s.cycle(-1)
+}
+
+func (s Status) cycle(delta int) Status {
+	idx := slices.Index(statusCycle, s)
+	if idx < 0 {
+		return statusCycle[0]
+	}
+
+	n := len(statusCycle)
+	return statusCycle[((idx+delta)%n+n)%n]
+}
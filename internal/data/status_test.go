@@ -0,0 +1,37 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestStatusCycle(t *testing.T) {
+	assert.Equal(t, data.StatusToDo, data.StatusNone.Next())
+	assert.Equal(t, data.StatusDone, data.StatusToDo.Next())
+	assert.Equal(t, data.StatusNone, data.StatusDone.Next())
+
+	assert.Equal(t, data.StatusDone, data.StatusNone.Prev())
+	assert.Equal(t, data.StatusToDo, data.StatusDone.Prev())
+	assert.Equal(t, data.StatusNone, data.StatusToDo.Prev())
+
+	// the statuses outside of the cycle go to its start
+	assert.Equal(t, data.StatusNone, data.StatusWaiting.Next())
+	assert.Equal(t, data.StatusNone, data.StatusCanceled.Prev())
+}
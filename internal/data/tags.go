@@ -0,0 +1,148 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"slices"
+	"strings"
+)
+
+// tagSeparator separates tags in the "tags" XML attribute, so it can
+// not be a part of a tag.
+const tagSeparator = ","
+
+// normalizeTag trims the tag and drops the separators from it.
+func normalizeTag(tag string) string {
+	return strings.TrimSpace(strings.ReplaceAll(tag, tagSeparator, ""))
+}
+
+// parseTags returns the sorted, deduplicated tags of the "tags" XML
+// attribute value.
+func parseTags(s string) []string {
+	var tags []string
+	for _, tag := range strings.Split(s, tagSeparator) {
+		if tag = normalizeTag(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	slices.Sort(tags)
+	return slices.Compact(tags)
+}
+
+// Tags returns the sorted list of the item tags.
+func (i *Item) Tags() []string {
+	return i.tags
+}
+
+// HasTag reports whether the item has the tag.
+func (i *Item) HasTag(tag string) bool {
+	_, found := slices.BinarySearch(i.tags, normalizeTag(tag))
+	return found
+}
+
+// AddTag adds the tag to the item, keeping the tags sorted. Adding
+// a tag the item already has does nothing.
+func (i *Item) AddTag(tag string) {
+	tag = normalizeTag(tag)
+	if tag == "" {
+		return
+	}
+
+	idx, found := slices.BinarySearch(i.tags, tag)
+	if !found {
+		i.setTags(slices.Insert(slices.Clone(i.tags), idx, tag))
+	}
+}
+
+// RemoveTag removes the tag from the item. Removing a tag the item
+// does not have does nothing.
+func (i *Item) RemoveTag(tag string) {
+	idx, found := slices.BinarySearch(i.tags, normalizeTag(tag))
+	if found {
+		i.setTags(slices.Delete(slices.Clone(i.tags), idx, idx+1))
+	}
+}
+
+// setTags replaces the item tags and records the change for undo. The
+// tag slices are never modified in place, so the old one stays intact.
+func (i *Item) setTags(tags []string) {
+	old := i.tags
+	i.tags = tags
+	i.workspace.recordEdit(i, func() { i.tags = old }, func() { i.tags = tags })
+}
+
+// GroupByTags moves the tagged children of the item under new group
+// items titled after the tags, one group per distinct tag. The groups
+// are appended after the untagged children in the tag order. It
+// returns the groups.
+//
+// An item has a single place in the tree, so a child having several
+// tags goes to the group of its first tag only, and only that tag is
+// removed from it. It keeps the other tags, so grouping the group
+// children again moves it further under the next tag.
+func (i *Item) GroupByTags() []*Item {
+	tagged := make(map[string][]*Item)
+	var tags []string
+	for c := i.head; c != nil; c = c.next {
+		if len(c.tags) > 0 {
+			tag := c.tags[0]
+			if _, ok := tagged[tag]; !ok {
+				tags = append(tags, tag)
+			}
+			tagged[tag] = append(tagged[tag], c)
+		}
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	slices.Sort(tags)
+
+	defer i.workspace.batch()()
+
+	var groups []*Item
+	for _, tag := range tags {
+		for _, c := range tagged[tag] {
+			c.RemoveTag(tag)
+		}
+		groups = append(groups, i.appendGroup(tag, tagged[tag]))
+	}
+
+	return groups
+}
+
+// UngroupToTag replaces the item with its children, tagging each of
+// them with the item title. It does nothing for the items without
+// a parent or a title.
+func (i *Item) UngroupToTag() {
+	if i.parent == nil || normalizeTag(i.title) == "" {
+		return
+	}
+
+	defer i.workspace.batch()()
+
+	for c := i.head; c != nil; {
+		next := c.next
+
+		c.AddTag(i.title)
+		c.MoveAbove(i)
+
+		c = next
+	}
+
+	i.Detach()
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemGroupByTags(t *testing.T) {
+	t.Run("NoTags", func(t *testing.T) {
+		w, a, b, _ := newTestItems()
+		root := w.Root()
+
+		root.Append(a)
+		root.Append(b)
+
+		assert.Nil(t, root.GroupByTags())
+		assertChildrenOrder(t, root, a, b)
+	})
+
+	t.Run("Grouping", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+		d := w.NewItem("ChildD")
+
+		root.Append(a)
+		root.Append(b)
+		root.Append(c)
+		root.Append(d)
+
+		a.AddTag("work")
+		b.AddTag("home")
+		b.AddTag("work")
+		d.AddTag("work")
+
+		groups := root.GroupByTags()
+		require.Len(t, groups, 2)
+
+		home, work := groups[0], groups[1]
+		assert.Equal(t, "home", home.Title())
+		assert.Equal(t, "work", work.Title())
+
+		assertChildrenOrder(t, root, c, home, work)
+		assertChildrenOrder(t, home, b)
+		assertChildrenOrder(t, work, a, d)
+
+		assert.Empty(t, a.Tags())
+		assert.Equal(t, []string{"work"}, b.Tags())
+		assert.Empty(t, d.Tags())
+
+		// the remaining tag groups the child again
+		groups = home.GroupByTags()
+		require.Len(t, groups, 1)
+		assertChildrenOrder(t, home, groups[0])
+		assertChildrenOrder(t, groups[0], b)
+		assert.Equal(t, "work", groups[0].Title())
+		assert.Empty(t, b.Tags())
+	})
+}
+
+func TestItemUngroupToTag(t *testing.T) {
+	t.Run("Ungrouping", func(t *testing.T) {
+		w, a, b, c := newTestItems()
+		root := w.Root()
+		group := w.NewItem("work")
+		d := w.NewItem("ChildD")
+
+		root.Append(a)
+		root.Append(group)
+		root.Append(d)
+		group.Append(b)
+		group.Append(c)
+
+		c.AddTag("home")
+		group.UngroupToTag()
+
+		assertChildrenOrder(t, root, a, b, c, d)
+		assertItemDetached(t, group)
+
+		assert.Equal(t, []string{"work"}, b.Tags())
+		assert.Equal(t, []string{"home", "work"}, c.Tags())
+		assert.Empty(t, a.Tags())
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		w, a, b, _ := newTestItems()
+		root := w.Root()
+
+		root.Append(a)
+		root.Append(b)
+		a.AddTag("work")
+
+		groups := root.GroupByTags()
+		require.Len(t, groups, 1)
+
+		groups[0].UngroupToTag()
+
+		assertChildrenOrder(t, root, b, a)
+		assert.Equal(t, []string{"work"}, a.Tags())
+	})
+}
+
+func TestItemTags(t *testing.T) {
+	w, a, _, _ := newTestItems()
+
+	a.AddTag("work")
+	a.AddTag(" home ")
+	a.AddTag("work")
+	a.AddTag("")
+	assert.Equal(t, []string{"home", "work"}, a.Tags())
+	assert.True(t, a.HasTag("home"))
+
+	a.RemoveTag("home")
+	a.RemoveTag("home")
+	assert.Equal(t, []string{"work"}, a.Tags())
+	assert.False(t, a.HasTag("home"))
+
+	w.Root().Append(a)
+	out, err := a.MarshalXMLBytes()
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `tags="work"`)
+}
+
+func TestItemTagsRoundTrip(t *testing.T) {
+	w, items := newSavedWorkspace(t)
+
+	items[0].AddTag("#urgent")
+	items[0].AddTag("@work")
+	items[2].AddTag("@home")
+
+	w = reloadWorkspace(t, w)
+
+	a := w.Root().Head()
+	b := a.Next()
+	c := b.Next()
+	assert.Equal(t, []string{"#urgent", "@work"}, a.Tags())
+	assert.Empty(t, b.Tags())
+	assert.Equal(t, []string{"@home"}, c.Tags())
+}
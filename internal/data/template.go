@@ -0,0 +1,105 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"embed"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// TemplatesDirectory is the directory of the templates within the
+// workspace directory.
+const TemplatesDirectory = "templates"
+
+const templateExt = ".xml"
+
+// builtinTemplates are the templates available without the templates
+// directory, unless a template of the same name overrides them.
+//
+//go:embed templates/*.xml
+var builtinTemplates embed.FS
+
+// ListTemplates returns the names of the templates in the templates
+// directory of the workspace directory and of the built-in ones,
+// sorted.
+func ListTemplates(directory string) ([]string, error) {
+	builtin, err := fs.Glob(builtinTemplates, "templates/*"+templateExt)
+	if err != nil {
+		return nil, err
+	}
+
+	own, err := filepath.Glob(filepath.Join(directory, TemplatesDirectory, "*"+templateExt))
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, p := range append(builtin, own...) {
+		name := strings.TrimSuffix(filepath.Base(p), templateExt)
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	return names, nil
+}
+
+// readTemplate returns the data of the named template, preferring the
+// templates directory to the built-in templates.
+func readTemplate(directory, name string) ([]byte, error) {
+	if err := validateDocumentName(name); err != nil {
+		return nil, fmt.Errorf("invalid template name %q", name)
+	}
+
+	data, err := os.ReadFile(filepath.Join(directory, TemplatesDirectory, name+templateExt))
+	if !errors.Is(err, fs.ErrNotExist) {
+		return data, err
+	}
+
+	data, err = builtinTemplates.ReadFile(path.Join("templates", name+templateExt))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("template %q not found", name)
+	}
+
+	return data, err
+}
+
+// LoadTemplate returns the subtree of the named template as a new item
+// not attached to any list. A template is an item element, as stored
+// in the workspace file, and the items made of it get new ids.
+func (w *Workspace) LoadTemplate(name string) (*Item, error) {
+	data, err := readTemplate(w.directory, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// the template ids stay in the scratch workspace
+	scratch := NewWorkspace("", "")
+	item := scratch.NewItem("")
+	if err := xml.Unmarshal(data, item); err != nil {
+		return nil, fmt.Errorf("failed to parse template %q: %w", name, err)
+	}
+
+	return item.Clone(w), nil
+}
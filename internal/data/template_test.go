@@ -0,0 +1,106 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestTemplates(t *testing.T) {
+	t.Run("Builtin", func(t *testing.T) {
+		w, _ := newSavedWorkspace(t)
+
+		names, err := data.ListTemplates(w.Directory())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"daily"}, names)
+
+		daily, err := w.LoadTemplate("daily")
+		require.NoError(t, err)
+		assert.Nil(t, daily.Parent())
+		assert.Equal(t, "Daily", daily.Title())
+
+		var titles []string
+		for c := daily.Head(); c != nil; c = c.Next() {
+			titles = append(titles, c.Title())
+		}
+		assert.Equal(t, []string{"Top priorities", "Meetings", "Notes", "Review"}, titles)
+		assert.Equal(t, data.StatusToDo, daily.Tail().Head().Status())
+
+		// the items are resolved once inserted
+		_, ok := w.GetByID(daily.Tail().Head().ID())
+		assert.False(t, ok)
+
+		w.Root().Append(daily)
+		got, ok := w.GetByID(daily.Tail().Head().ID())
+		assert.True(t, ok)
+		assert.Same(t, daily.Tail().Head(), got)
+	})
+
+	t.Run("Own", func(t *testing.T) {
+		w, items := newSavedWorkspace(t)
+
+		// the subtree is stored the same way as in the workspace file
+		child := w.NewItem("Agenda")
+		items[1].Append(child)
+		raw, err := items[1].MarshalXMLBytes()
+		require.NoError(t, err)
+
+		dir := filepath.Join(w.Directory(), data.TemplatesDirectory)
+		require.NoError(t, os.Mkdir(dir, 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "meeting.xml"), raw, 0600))
+
+		names, err := data.ListTemplates(w.Directory())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"daily", "meeting"}, names)
+
+		// every insertion gets new ids
+		first, err := w.LoadTemplate("meeting")
+		require.NoError(t, err)
+		second, err := w.LoadTemplate("meeting")
+		require.NoError(t, err)
+
+		for _, m := range []*data.Item{first, second} {
+			assert.Equal(t, "B", m.Title())
+			assert.Equal(t, "Agenda", m.Head().Title())
+			assert.NotEqual(t, items[1].ID(), m.ID())
+			assert.NotEqual(t, child.ID(), m.Head().ID())
+		}
+		assert.NotEqual(t, first.ID(), second.ID())
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		w, _ := newSavedWorkspace(t)
+
+		_, err := w.LoadTemplate("missing")
+		assert.ErrorContains(t, err, `template "missing" not found`)
+
+		_, err = w.LoadTemplate("../daily")
+		assert.Error(t, err)
+
+		dir := filepath.Join(w.Directory(), data.TemplatesDirectory)
+		require.NoError(t, os.Mkdir(dir, 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.xml"), []byte("<item>"), 0600))
+
+		_, err = w.LoadTemplate("broken")
+		assert.ErrorContains(t, err, `failed to parse template "broken"`)
+	})
+}
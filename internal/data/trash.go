@@ -0,0 +1,183 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	xmlElemTrash = "trash"
+
+	xmlItemAttrDeleted = "deleted"
+	xmlItemAttrOrigin  = "origin"
+)
+
+// newTrash returns the item holding the trashed items. It is not a part
+// of the workspace tree, so the trashed items are not displayed, found
+// or exported.
+func newTrash(w *Workspace) *Item {
+	return &Item{workspace: w, id: uuid.New(), title: "Trash"}
+}
+
+// Trash moves the item with its descendants to the trash, stamping it
+// with the deletion time and the parent it is restored to.
+func (i *Item) Trash() {
+	w := i.workspace
+	defer w.batch()()
+
+	origin := i.parent.id
+	defer w.recordMove(i)()
+
+	i.stampDeletion(time.Now(), origin)
+	w.trash.prependChild(i)
+}
+
+// Restore moves the trashed item back under its original parent, or
+// under the real root if the parent is no longer in the tree. It
+// returns the parent the item is restored to.
+func (i *Item) Restore() *Item {
+	w := i.workspace
+	defer w.batch()()
+
+	parent := i.Origin()
+	if parent == nil {
+		parent = w.realRoot
+	}
+
+	defer w.recordMove(i)()
+
+	i.stampDeletion(time.Time{}, uuid.Nil)
+	parent.appendChild(i)
+
+	return parent
+}
+
+// stampDeletion sets the deletion time and the original parent id of
+// the item.
+func (i *Item) stampDeletion(deleted time.Time, origin uuid.UUID) {
+	oldDeleted, oldOrigin := i.deleted, i.origin
+
+	i.workspace.recordEdit(i,
+		func() { i.deleted, i.origin = oldDeleted, oldOrigin },
+		func() { i.deleted, i.origin = deleted, origin },
+	)
+
+	i.deleted, i.origin = deleted, origin
+}
+
+// Deleted returns the time the item was moved to the trash, or the zero
+// time if it is not trashed.
+func (i *Item) Deleted() time.Time {
+	return i.deleted
+}
+
+// Origin returns the parent the trashed item was deleted from, or nil
+// if the parent is no longer in the tree, e.g. trashed too.
+func (i *Item) Origin() *Item {
+	if i.origin == uuid.Nil {
+		return nil
+	}
+
+	parent, ok := i.workspace.GetByID(i.origin)
+	if !ok || !parent.inTree() {
+		return nil
+	}
+
+	return parent
+}
+
+// Trashed returns the items in the trash, the most recently deleted
+// first.
+func (w *Workspace) Trashed() []*Item {
+	var items []*Item
+	for c := w.trash.head; c != nil; c = c.next {
+		items = append(items, c)
+	}
+
+	return items
+}
+
+// EmptyTrash permanently removes the trashed items, so they are no
+// longer resolved by GetByID either. It returns the number of the
+// removed items.
+func (w *Workspace) EmptyTrash() int {
+	defer w.batch()()
+
+	n := 0
+	for w.trash.head != nil {
+		w.trash.head.Detach()
+		n++
+	}
+
+	return n
+}
+
+// encodeTrash writes the trashed items, if any.
+func (w *Workspace) encodeTrash(e *xml.Encoder) error {
+	if w.trash.head == nil {
+		return nil
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: xmlElemTrash}}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for c := w.trash.head; c != nil; c = c.next {
+		if err := e.Encode(c); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// decodeTrash reads the trashed items.
+func (w *Workspace) decodeTrash(d *xml.Decoder, start xml.StartElement) error {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		switch se := tok.(type) {
+		case xml.StartElement:
+			if se.Name.Local != xmlElemItem {
+				if err := d.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+
+			c := w.NewItem("")
+			if err := d.DecodeElement(c, &se); err != nil {
+				return err
+			}
+			w.trash.appendChild(c)
+		case xml.EndElement:
+			if se.Name == start.Name {
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestTrash(t *testing.T) {
+	t.Run("TrashAndRestore", func(t *testing.T) {
+		w, items := newSavedWorkspace(t)
+		a, b, c := items[0], items[1], items[2]
+		child := w.NewItem("Child")
+		b.Append(child)
+
+		child.Trash()
+		b.Trash()
+		assertChildrenOrder(t, w.Root(), a, c)
+		assert.Equal(t, []*data.Item{b, child}, w.Trashed())
+		assert.False(t, b.Deleted().IsZero())
+		assert.Same(t, w.Root(), b.Origin())
+
+		// the trash is stored
+		w = reloadWorkspace(t, w)
+		trashed := w.Trashed()
+		require.Len(t, trashed, 2)
+		assert.Equal(t, b.ID(), trashed[0].ID())
+		assert.Equal(t, child.ID(), trashed[1].ID())
+		b, child = trashed[0], trashed[1]
+		assert.Same(t, w.Root(), b.Origin())
+		assert.Nil(t, child.Origin())
+
+		// the original parent of the child is trashed too
+		assert.Same(t, w.Root(), child.Restore())
+		assert.Same(t, w.Root(), b.Restore())
+		assert.Equal(t, "Child", w.Root().Tail().Prev().Title())
+		assert.Same(t, b, w.Root().Tail())
+		assert.True(t, b.Deleted().IsZero())
+		assert.Nil(t, b.Origin())
+		assert.Empty(t, w.Trashed())
+
+		a = w.Root().Head()
+		a.Trash()
+		assert.Same(t, a, w.Undo())
+		assert.Same(t, a, w.Root().Head())
+		assert.True(t, a.Deleted().IsZero())
+	})
+
+	t.Run("RestoreToParent", func(t *testing.T) {
+		w, items := newSavedWorkspace(t)
+		child := w.NewItem("Child")
+		items[1].Append(child)
+
+		child.Trash()
+		assertChildrenListEmpty(t, items[1])
+
+		assert.Same(t, items[1], child.Restore())
+		assertChildrenOrder(t, items[1], child)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		w, items := newSavedWorkspace(t)
+		child := w.NewItem("Child")
+		items[1].Append(child)
+		child.Trash()
+		items[0].Trash()
+		items[1].Trash()
+
+		assert.Equal(t, 3, w.EmptyTrash())
+		assert.Empty(t, w.Trashed())
+		_, ok := w.GetByID(items[1].ID())
+		assert.False(t, ok)
+		assert.Nil(t, child.Origin())
+
+		w.Undo()
+		assert.Len(t, w.Trashed(), 3)
+		w.Redo()
+
+		w = reloadWorkspace(t, w)
+		assert.Empty(t, w.Trashed())
+		assertChildrenOrder(t, w.Root(), w.Root().Head())
+		assert.Equal(t, "C", w.Root().Head().Title())
+	})
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import "errors"
+
+// SkipChildren is returned by the Walk functions to skip the
+// descendants of the visited item. It is not returned by the walk.
+var SkipChildren = errors.New("skip the children")
+
+// Walk calls fn for the descendants of the item, not including the
+// item itself, in the document order. It stops on the first error
+// returned by fn, other than SkipChildren, and returns it. The tree is
+// walked without recursion, so deep trees do not grow the call stack.
+// The structure of the tree must not be changed by fn.
+func (i *Item) Walk(fn func(*Item) error) error {
+	return i.walk(fn, false)
+}
+
+// WalkDisplayed is Walk skipping the descendants of the collapsed
+// items.
+func (i *Item) WalkDisplayed(fn func(*Item) error) error {
+	return i.walk(fn, true)
+}
+
+func (i *Item) walk(fn func(*Item) error, displayed bool) error {
+	// the items to visit next, each one followed by its next siblings;
+	// the array keeps the shallow walks from allocating
+	var array [32]*Item
+	stack := append(array[:0], i.head)
+	for len(stack) > 0 {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if c == nil {
+			continue
+		}
+
+		err := fn(c)
+		if err != nil && err != SkipChildren {
+			return err
+		}
+
+		stack = append(stack, c.next)
+		if err == nil && !(displayed && c.collapsed) {
+			stack = append(stack, c.head)
+		}
+	}
+
+	return nil
+}
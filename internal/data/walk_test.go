@@ -0,0 +1,116 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+// newWalkTree builds the tree
+//
+//	A
+//	  B
+//	    C
+//	  D
+//	E
+//	  F
+func newWalkTree() (*data.Workspace, map[string]*data.Item) {
+	w := data.NewWorkspace("", "Root")
+	items := make(map[string]*data.Item)
+	for _, title := range []string{"A", "B", "C", "D", "E", "F"} {
+		items[title] = w.NewItem(title)
+	}
+
+	w.Root().Append(items["A"])
+	items["A"].Append(items["B"])
+	items["B"].Append(items["C"])
+	items["A"].Append(items["D"])
+	w.Root().Append(items["E"])
+	items["E"].Append(items["F"])
+
+	return w, items
+}
+
+func TestItemWalk(t *testing.T) {
+	collect := func(titles *[]string, skip string) func(*data.Item) error {
+		return func(item *data.Item) error {
+			*titles = append(*titles, item.Title())
+			if item.Title() == skip {
+				return data.SkipChildren
+			}
+			return nil
+		}
+	}
+
+	t.Run("PreOrder", func(t *testing.T) {
+		w, items := newWalkTree()
+
+		var titles []string
+		assert.NoError(t, w.Root().Walk(collect(&titles, "")))
+		assert.Equal(t, []string{"A", "B", "C", "D", "E", "F"}, titles)
+
+		titles = nil
+		assert.NoError(t, items["A"].Walk(collect(&titles, "")))
+		assert.Equal(t, []string{"B", "C", "D"}, titles)
+
+		titles = nil
+		assert.NoError(t, items["C"].Walk(collect(&titles, "")))
+		assert.Empty(t, titles)
+	})
+
+	t.Run("SkipChildren", func(t *testing.T) {
+		w, _ := newWalkTree()
+
+		var titles []string
+		assert.NoError(t, w.Root().Walk(collect(&titles, "B")))
+		assert.Equal(t, []string{"A", "B", "D", "E", "F"}, titles)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		w, _ := newWalkTree()
+		errStop := errors.New("stop")
+
+		var titles []string
+		err := w.Root().Walk(func(item *data.Item) error {
+			titles = append(titles, item.Title())
+			if item.Title() == "C" {
+				return errStop
+			}
+			return nil
+		})
+		assert.ErrorIs(t, err, errStop)
+		assert.Equal(t, []string{"A", "B", "C"}, titles)
+	})
+
+	t.Run("Displayed", func(t *testing.T) {
+		w, items := newWalkTree()
+		items["B"].SetCollapsed(true, false)
+		items["E"].SetCollapsed(true, false)
+
+		var titles []string
+		assert.NoError(t, w.Root().WalkDisplayed(collect(&titles, "")))
+		assert.Equal(t, []string{"A", "B", "D", "E"}, titles)
+
+		// the walked item itself may be collapsed
+		titles = nil
+		assert.NoError(t, items["E"].WalkDisplayed(collect(&titles, "")))
+		assert.Equal(t, []string{"F"}, titles)
+	})
+}
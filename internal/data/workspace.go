@@ -15,66 +15,186 @@
 package data
 
 import (
+	"cmp"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// DefaultBackupLimit is the number of the most recent backups kept by
+// default.
+const DefaultBackupLimit = 10
+
+// DefaultDocument is the name of the document loaded by LoadWorkspace.
+const DefaultDocument = "workspace"
+
 const (
-	workspaceFilename = "workspace.xml"
-	storageVersion    = 2
+	documentExt    = ".xml"
+	storageVersion = 2
 
 	xmlElemItem          = "item"
 	xmlItemAttrId        = "id"
 	xmlItemAttrStatus    = "status"
+	xmlItemAttrPriority  = "priority"
 	xmlItemAttrCollapsed = "collapsed"
+	xmlItemAttrTags      = "tags"
+	xmlItemAttrDue       = "due"
+	xmlItemAttrCreated   = "created"
+	xmlItemAttrModified  = "modified"
+	xmlItemAttrCompleted = "completed"
 
 	xmlElemTitle = "title"
+	xmlElemNote  = "note"
+
+	xmlElemRef   = "ref"
+	xmlRefAttrId = "id"
 
 	xmlElemWorkspace        = "oli-workspace"
 	xmlWorkspaceAttrVersion = "version"
 	xmlWorkspaceAttrCursor  = "cursor"
 	xmlWorkspaceAttrRoot    = "root"
+	xmlWorkspaceAttrTop     = "top"
 )
 
+// Workspace is the item tree with its view state.
+//
+// The workspace fields, the item index and the undo history are guarded
+// by a mutex. The exported methods of the workspace take it: the read
+// lock for the getters (Root, Top, Cursor, Dirty, GetByID, RestoredFrom,
+// Encrypted), the write lock for the rest, and Save holds the write lock
+// for the whole save, so the tree is not changed while it is written.
+// The exceptions are Directory, Document and Path, which return the
+// fields fixed once the workspace is loaded, and Inbox, which changes
+// the tree through the item methods. The item methods take the lock to
+// record the changes and to update the index of the moved items. The
+// unexported methods expect the caller to hold the lock, and no locking
+// method calls another one, since the locks are not reentrant.
+//
+// The fields of the items are not guarded: the items are changed by
+// the goroutine owning the workspace only, such as the update loop of
+// the app.
 type Workspace struct {
+	mu sync.RWMutex
+
 	directory string
 
+	// name of the document file in the directory, without the
+	// extension
+	document string
+
+	// keep a timestamped backup of the workspace file on save, and at
+	// most that many of them
+	backups     bool
+	backupLimit int
+
+	// status names of the imported files mapped to the statuses
+	statusAliases map[string]Status
+
+	// complete the parents whose children are all completed, and
+	// reopen them when a child is reopened
+	autoComplete bool
+
+	// items of the tree and the trash by id, see GetByID
 	itemIndex map[uuid.UUID]*Item
 
 	realRoot *Item
 	root     *Item
 	cursor   *Item
+
+	// parent of the deleted items, outside of the tree
+	trash *Item
+
+	// ids of the items marked with the runes
+	marks map[rune]uuid.UUID
+
+	// topmost visible item of the outline view
+	top *Item
+
+	// undo and redo stacks
+	history history
+
+	// whether there are changes made since the last save
+	dirty bool
+
+	// name of the backup loaded instead of the corrupt workspace file
+	restoredFrom string
+
+	// gzip the workspace file on save
+	compress bool
+
+	// state of the workspace file when it was last loaded or saved
+	diskState diskState
+
+	// passphrase the workspace file is encrypted with, or an empty
+	// string if it is stored in plain text
+	passphrase string
 }
 
 func NewWorkspace(directory, rootTitle string) *Workspace {
 	w := &Workspace{
-		directory: directory,
-		itemIndex: make(map[uuid.UUID]*Item),
+		directory:   directory,
+		document:    DefaultDocument,
+		backups:     true,
+		backupLimit: DefaultBackupLimit,
+		itemIndex:   make(map[uuid.UUID]*Item),
 	}
 
 	w.realRoot = w.NewItem(rootTitle)
+	w.itemIndex[w.realRoot.id] = w.realRoot
 	w.root = w.realRoot
 	w.cursor = w.realRoot
+	w.trash = newTrash(w)
 
 	return w
 }
 
-func LoadWorkspace(directory string) (*Workspace, error) {
-	p := filepath.Join(directory, workspaceFilename)
-	w := NewWorkspace(directory, "Home")
+// LoadWorkspace loads the default document from the directory, see
+// LoadDocument.
+func LoadWorkspace(directory string, passphrase PassphraseFunc) (*Workspace, error) {
+	return LoadDocument(directory, DefaultDocument, passphrase)
+}
 
-	if _, err := os.Stat(p); os.IsNotExist(err) {
+// LoadDocument loads the named document file from the directory,
+// creating it if absent. If the file is malformed, the most recent
+// backup which can be decoded is loaded instead, see RestoredFrom. The
+// passphrase function is called if the file is encrypted, it may be
+// nil if no passphrase is available.
+func LoadDocument(directory, name string, passphrase PassphraseFunc) (*Workspace, error) {
+	return loadDocument(directory, name, passphrase, nil)
+}
+
+// loadDocument is LoadDocument calling the setup function, if not nil,
+// on the loaded workspace before it is saved for the first time, so the
+// settings apply to the created or migrated file too.
+func loadDocument(directory, name string, passphrase PassphraseFunc, setup func(w *Workspace)) (*Workspace, error) {
+	if err := validateDocumentName(name); err != nil {
+		return nil, err
+	}
+
+	p := filepath.Join(directory, name+documentExt)
+
+	info, err := os.Stat(p)
+	if os.IsNotExist(err) {
+		w := NewWorkspace(directory, "Home")
+		w.document = name
 		i := w.NewItem("")
-		w.root.Append(i)
+		w.root.appendChild(i)
+		w.index(i)
 		w.cursor = i
 
+		if setup != nil {
+			setup(w)
+		}
 		return w, w.Save()
 	} else if err != nil {
 		return nil, err
@@ -85,27 +205,329 @@ func LoadWorkspace(directory string) (*Workspace, error) {
 		return nil, err
 	}
 
-	return w, xml.Unmarshal(data, w)
+	var key string
+	if isEncrypted(data) {
+		if passphrase == nil {
+			return nil, ErrEncrypted
+		}
+
+		if key, err = passphrase(); err != nil {
+			return nil, err
+		}
+
+		// a wrong passphrase would not open the backups either
+		if data, err = decrypt(data, key); err != nil {
+			return nil, err
+		}
+	}
+
+	w, migrated, err := decodeWorkspace(directory, name, data)
+	if err != nil {
+		// the files of the other versions or with invalid values are
+		// not replaced by the backups, only the broken ones
+		if !isParseError(err) {
+			return nil, err
+		}
+
+		w, err := restoreBackup(directory, name, key, err)
+		if err != nil {
+			return nil, err
+		}
+
+		w.diskState = newDiskState(info)
+		if setup != nil {
+			setup(w)
+		}
+		return w, nil
+	}
+	w.passphrase = key
+	w.diskState = newDiskState(info)
+	if setup != nil {
+		setup(w)
+	}
+
+	// store the file in the current version, keeping the old one
+	// as a backup
+	if migrated {
+		return w, w.Save()
+	}
+
+	return w, nil
+}
+
+// decodeWorkspace decodes the workspace file data, converted to the
+// current storage version. It reports whether the data was converted.
+func decodeWorkspace(directory, name string, data []byte) (*Workspace, bool, error) {
+	w := NewWorkspace(directory, "Home")
+	w.document = name
+
+	if isCompressed(data) {
+		var err error
+		if data, err = decompress(data); err != nil {
+			return nil, false, err
+		}
+	}
+
+	data, migrated, err := migrate(data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := xml.Unmarshal(data, w); err != nil {
+		return nil, false, err
+	}
+
+	// building the loaded tree is not undoable
+	w.clearHistory()
+	w.dirty = false
+
+	return w, migrated, nil
+}
+
+// isParseError reports whether the error is of parsing a malformed
+// file, such as the one truncated by an interrupted write or left
+// empty.
+func isParseError(err error) bool {
+	var syntaxErr *xml.SyntaxError
+	return errors.As(err, &syntaxErr) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, errMissingRootElement)
+}
+
+// restoreBackup loads the most recent backup which can be decoded,
+// decrypting it with the passphrase if needed. The workspace is marked
+// as changed, since it differs from the file. If there is no such
+// backup, the error of loading the workspace file is returned.
+func restoreBackup(directory, name, passphrase string, loadErr error) (*Workspace, error) {
+	backups, err := listBackups(directory, name+documentExt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range backups {
+		data, err := os.ReadFile(b.path)
+		if err != nil {
+			continue
+		}
+
+		if isEncrypted(data) {
+			if data, err = decrypt(data, passphrase); err != nil {
+				continue
+			}
+		}
+
+		w, _, err := decodeWorkspace(directory, name, data)
+		if err != nil {
+			continue
+		}
+
+		w.restoredFrom = filepath.Base(b.path)
+		w.passphrase = passphrase
+		w.dirty = true
+
+		return w, nil
+	}
+
+	return nil, fmt.Errorf("failed to load %s and no backup could be restored: %w", name+documentExt, loadErr)
 }
 
-// NewItem returns a new item not attached to any list.
+// validateDocumentName checks that the document name names a file in
+// the workspace directory.
+func validateDocumentName(name string) error {
+	if name == "" || strings.HasPrefix(name, ".") || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid document name %q", name)
+	}
+
+	return nil
+}
+
+// ListDocuments returns the names of the documents in the directory,
+// sorted.
+func ListDocuments(directory string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(directory, "*"+documentExt))
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, p := range paths {
+		name := strings.TrimSuffix(filepath.Base(p), documentExt)
+		if validateDocumentName(name) != nil {
+			continue
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names, nil
+}
+
+// RestoredFrom returns the name of the backup the workspace was loaded
+// from because the workspace file could not be decoded, or an empty
+// string if the workspace file was loaded.
+func (w *Workspace) RestoredFrom() string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.restoredFrom
+}
+
+// NewItem returns a new item not attached to any list. The item is
+// resolved by GetByID once it is attached to the workspace.
 func (w *Workspace) NewItem(title string) *Item {
-	return &Item{
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := time.Now()
+
+	i := &Item{
 		workspace: w,
 		id:        uuid.New(),
 		title:     title,
+		created:   now,
+		modified:  now,
 	}
+
+	return i
+}
+
+// Inbox returns the top-level item with the title, creating it if
+// absent, or the real root if the title is empty.
+func (w *Workspace) Inbox(title string) *Item {
+	if title == "" {
+		return w.realRoot
+	}
+
+	inbox, _ := w.realRoot.FindOrCreateChild(title)
+	return inbox
+}
+
+// Directory returns the directory the workspace is stored in.
+func (w *Workspace) Directory() string {
+	return w.directory
+}
+
+// Document returns the name of the workspace document.
+func (w *Workspace) Document() string {
+	return w.document
+}
+
+// Path returns the path of the workspace file.
+func (w *Workspace) Path() string {
+	return filepath.Join(w.directory, w.filename())
+}
+
+func (w *Workspace) filename() string {
+	return w.document + documentExt
+}
+
+// diskState identifies a version of the workspace file.
+type diskState struct {
+	modTime time.Time
+	size    int64
+}
+
+func newDiskState(info os.FileInfo) diskState {
+	return diskState{modTime: info.ModTime(), size: info.Size()}
+}
+
+// currentDiskState returns the state of the workspace file, or a zero
+// state if there is no file.
+func (w *Workspace) currentDiskState() diskState {
+	info, err := os.Stat(w.Path())
+	if err != nil {
+		return diskState{}
+	}
+
+	return newDiskState(info)
+}
+
+// ChangedOnDisk reports whether the workspace file was changed since it
+// was loaded or saved by the workspace, i.e. by someone else.
+func (w *Workspace) ChangedOnDisk() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.changedOnDisk()
+}
+
+func (w *Workspace) changedOnDisk() bool {
+	current := w.currentDiskState()
+	return !current.modTime.Equal(w.diskState.modTime) || current.size != w.diskState.size
+}
+
+// IgnoreDiskChanges makes the current workspace file the known one, so
+// ChangedOnDisk no longer reports its changes.
+func (w *Workspace) IgnoreDiskChanges() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.diskState = w.currentDiskState()
+}
+
+// Reload loads the workspace file again, discarding the changes not
+// saved. The settings of the workspace, such as the backups and the
+// passphrase, are kept, so a plain file is encrypted on the next save
+// if the workspace is.
+func (w *Workspace) Reload() (*Workspace, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.open(w.document)
+}
+
+// Open loads the named document from the workspace directory, creating
+// it if absent, with the settings of the workspace. The passphrase of
+// the workspace is tried if the document is encrypted, and encrypts the
+// plain or created document if the workspace is encrypted, as the
+// other settings apply from its first save on.
+func (w *Workspace) Open(name string) (*Workspace, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.open(name)
+}
+
+func (w *Workspace) open(name string) (*Workspace, error) {
+	var passphrase PassphraseFunc
+	if w.passphrase != "" {
+		passphrase = func() (string, error) { return w.passphrase, nil }
+	}
+
+	return loadDocument(w.directory, name, passphrase, func(loaded *Workspace) {
+		loaded.backups = w.backups
+		loaded.backupLimit = w.backupLimit
+		loaded.statusAliases = w.statusAliases
+		loaded.autoComplete = w.autoComplete
+		loaded.compress = w.compress
+
+		// the encrypted files keep their passphrase, the plain ones
+		// are encrypted from the next save on
+		if loaded.passphrase == "" {
+			loaded.passphrase = w.passphrase
+		}
+	})
 }
 
 func (w *Workspace) Root() *Item {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
 	return w.root
 }
 
 func (w *Workspace) SetRoot(item *Item) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
 	w.root = item
 }
 
 func (w *Workspace) ZoomOut() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
 	if w.root.parent == nil {
 		return
 	}
@@ -116,14 +538,104 @@ func (w *Workspace) ZoomOut() {
 	w.root = w.root.parent
 }
 
+// Top returns the topmost visible item of the outline view, or nil
+// if it is unknown or not displayed under the current root.
+func (w *Workspace) Top() *Item {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.visibleTop()
+}
+
+func (w *Workspace) visibleTop() *Item {
+	if w.top == nil || w.top.Depth() < 1 {
+		return nil
+	}
+
+	return w.top
+}
+
+// SetTop remembers the topmost visible item of the outline view, so
+// the scroll position survives saving and loading the workspace.
+func (w *Workspace) SetTop(item *Item) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.top = item
+}
+
 func (w *Workspace) Cursor() *Item {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
 	return w.cursor
 }
 
 func (w *Workspace) SetCursor(item *Item) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
 	w.cursor = item
 }
 
+// SetBackupLimit sets the number of the most recent backups kept on
+// save. The older ones are removed. A limit of zero or less keeps all
+// the backups.
+func (w *Workspace) SetBackupLimit(n int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.backupLimit = max(n, 0)
+}
+
+// SetAutoComplete enables or disables completing the items with
+// a status when all their children with a status are completed by
+// SetStatus, and reopening them when one of the children is reopened.
+// It is disabled by default.
+func (w *Workspace) SetAutoComplete(enabled bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.autoComplete = enabled
+}
+
+// SetPassphrase sets the passphrase the workspace file is encrypted with
+// on save. An empty passphrase stores the file in plain text.
+func (w *Workspace) SetPassphrase(passphrase string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.passphrase = passphrase
+}
+
+// Encrypted reports whether the workspace file is encrypted on save.
+func (w *Workspace) Encrypted() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.passphrase != ""
+}
+
+// SetCompress sets whether the workspace file is gzipped on save. Both
+// the compressed and the plain files are loaded.
+func (w *Workspace) SetCompress(enabled bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.compress = enabled
+}
+
+// SetBackups enables or disables the backup of the workspace file
+// made before it is overwritten by Save. Backups are enabled by default.
+func (w *Workspace) SetBackups(enabled bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.backups = enabled
+}
+
+// MarshalXML encodes the workspace without taking the lock, Save holds
+// it while encoding.
 func (w *Workspace) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	start.Name.Local = xmlElemWorkspace
 	start.Attr = []xml.Attr{
@@ -132,11 +644,26 @@ func (w *Workspace) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		{Name: xml.Name{Local: xmlWorkspaceAttrRoot}, Value: w.root.id.String()},
 	}
 
+	if top := w.visibleTop(); top != nil {
+		start.Attr = append(start.Attr, xml.Attr{
+			Name:  xml.Name{Local: xmlWorkspaceAttrTop},
+			Value: top.id.String(),
+		})
+	}
+
 	if err := e.EncodeToken(start); err != nil {
 		return err
 	}
 
-	if err := e.Encode(w.root.RealRoot()); err != nil {
+	if err := e.Encode(w.realRoot); err != nil {
+		return err
+	}
+
+	if err := w.encodeTrash(e); err != nil {
+		return err
+	}
+
+	if err := w.encodeMarks(e); err != nil {
 		return err
 	}
 
@@ -146,6 +673,7 @@ func (w *Workspace) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 func (w *Workspace) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	var cursorUUID uuid.UUID
 	var rootUUID uuid.UUID
+	var topUUID uuid.UUID
 
 	for _, attr := range start.Attr {
 		switch attr.Name.Local {
@@ -167,6 +695,12 @@ func (w *Workspace) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 			if err != nil {
 				return err
 			}
+		case xmlWorkspaceAttrTop:
+			var err error
+			topUUID, err = uuid.Parse(attr.Value)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
@@ -186,6 +720,14 @@ func (w *Workspace) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 				if err := d.DecodeElement(w.realRoot, &se); err != nil {
 					return err
 				}
+			case xmlElemTrash:
+				if err := w.decodeTrash(d, se); err != nil {
+					return err
+				}
+			case xmlElemMark:
+				if err := w.decodeMark(d, se); err != nil {
+					return err
+				}
 			default:
 				if err := d.Skip(); err != nil {
 					return err
@@ -198,26 +740,329 @@ func (w *Workspace) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		}
 	}
 
-	w.root = w.itemIndex[rootUUID]
-	w.cursor = w.itemIndex[cursorUUID]
+	w.reindex()
+
+	w.root = w.lookupItem(rootUUID)
+	w.cursor = w.lookupItem(cursorUUID)
+	w.top = w.itemIndex[topUUID]
 
 	return nil
 }
 
+// GetByID returns the item with the id in the workspace tree or in the
+// trash. The detached items are not resolved, until an undo attaches
+// them again.
+func (w *Workspace) GetByID(id uuid.UUID) (*Item, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	i, ok := w.itemIndex[id]
+	return i, ok
+}
+
+// index adds the item and its descendants to the id index.
+func (w *Workspace) index(i *Item) {
+	w.itemIndex[i.id] = i
+	i.Walk(func(c *Item) error {
+		w.itemIndex[c.id] = c
+		return nil
+	})
+}
+
+// unindex removes the item and its descendants from the id index.
+func (w *Workspace) unindex(i *Item) {
+	delete(w.itemIndex, i.id)
+	i.Walk(func(c *Item) error {
+		delete(w.itemIndex, c.id)
+		return nil
+	})
+}
+
+// updateIndex indexes the item moved into the workspace, or unindexes
+// the one moved out of it, together with the descendants.
+func (w *Workspace) updateIndex(i *Item, wasAttached bool) {
+	switch attached := i.inWorkspace(); {
+	case attached && !wasAttached:
+		w.index(i)
+	case !attached && wasAttached:
+		w.unindex(i)
+	}
+}
+
+// reindex rebuilds the id index from the tree and the trash, after
+// they were built without moving the items.
+func (w *Workspace) reindex() {
+	clear(w.itemIndex)
+	w.index(w.realRoot)
+	for c := w.trash.head; c != nil; c = c.next {
+		w.index(c)
+	}
+}
+
+// lookupItem returns the item with the id, or the real root if there is
+// no such item.
+func (w *Workspace) lookupItem(id uuid.UUID) *Item {
+	if i, ok := w.itemIndex[id]; ok {
+		return i
+	}
+
+	return w.realRoot
+}
+
+// ErrChangedOnDisk is returned by Save when the workspace file was
+// changed by someone else since it was loaded or saved.
+var ErrChangedOnDisk = errors.New("file changed on disk since load, not saving — reload first")
+
+// Save writes the workspace file. The data is written to a temporary
+// file first, which then replaces the workspace file, so the latter is
+// never left partially written. If backups are enabled, the replaced
+// file is kept as a timestamped backup. An encrypted workspace keeps
+// no plain backups: the plain file is not backed up, and the plain
+// backups left from before the encryption are removed. The file
+// changed on disk by someone else is not overwritten, see
+// ErrChangedOnDisk and ForceSave.
 func (w *Workspace) Save() error {
-	p := filepath.Join(w.directory, workspaceFilename)
-	if _, err := os.Stat(p); err == nil {
-		backupFilename := fmt.Sprintf("%s.bak.%d", workspaceFilename, time.Now().Unix())
-		backupPath := filepath.Join(w.directory, backupFilename)
-		if err := os.Rename(p, backupPath); err != nil {
+	return w.saveFile(false)
+}
+
+// ForceSave is Save overwriting the workspace file even if it was
+// changed on disk.
+func (w *Workspace) ForceSave() error {
+	return w.saveFile(true)
+}
+
+func (w *Workspace) saveFile(force bool) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !force && w.changedOnDisk() {
+		return ErrChangedOnDisk
+	}
+
+	data, err := xml.MarshalIndent(w, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// the compressed data is encrypted, since the encrypted one does
+	// not compress
+	if w.compress {
+		if data, err = compress(data); err != nil {
 			return err
 		}
 	}
 
-	data, err := xml.MarshalIndent(w, "", "  ")
+	if w.passphrase != "" {
+		if data, err = encrypt(data, w.passphrase); err != nil {
+			return err
+		}
+	}
+
+	return w.save(func(f io.Writer) error {
+		_, err := f.Write(data)
+		return err
+	})
+}
+
+func (w *Workspace) save(write func(f io.Writer) error) error {
+	p := w.Path()
+
+	tmp, err := writeTempFile(w.directory, w.filename(), write)
+	if err != nil {
+		return err
+	}
+
+	backup := w.backups
+	if backup && w.passphrase != "" {
+		// the plain file is not kept as a backup of the encrypted one
+		encrypted, err := isEncryptedFile(p)
+		if err != nil && !os.IsNotExist(err) {
+			os.Remove(tmp)
+			return err
+		}
+		backup = encrypted
+	}
+
+	if _, err := os.Stat(p); err == nil && backup {
+		backupFilename := fmt.Sprintf("%s.bak.%d", w.filename(), time.Now().Unix())
+		if err := linkBackup(p, filepath.Join(w.directory, backupFilename)); err != nil {
+			os.Remove(tmp)
+			return err
+		}
+	}
+
+	if err := os.Rename(tmp, p); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	info, err := os.Stat(p)
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(p, data, 0600)
+	w.diskState = newDiskState(info)
+	w.dirty = false
+
+	if w.passphrase != "" {
+		if err := removePlainBackups(w.directory, w.filename()); err != nil {
+			return err
+		}
+	}
+
+	if w.backups {
+		return w.pruneBackups()
+	}
+
+	return nil
+}
+
+// backup is a workspace file backup made by Save.
+type backup struct {
+	path      string
+	timestamp int64
+}
+
+// listBackups returns the backups of the file in the directory, the
+// most recent first. They are ordered by the timestamp in their names
+// rather than by the modification time.
+func listBackups(directory, filename string) ([]backup, error) {
+	paths, err := filepath.Glob(filepath.Join(directory, filename+".bak.*"))
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []backup
+	for _, p := range paths {
+		suffix := strings.TrimPrefix(filepath.Base(p), filename+".bak.")
+		ts, err := strconv.ParseInt(suffix, 10, 64)
+		if err != nil {
+			// not a backup made by Save
+			continue
+		}
+		backups = append(backups, backup{path: p, timestamp: ts})
+	}
+
+	slices.SortFunc(backups, func(a, b backup) int {
+		return cmp.Compare(b.timestamp, a.timestamp)
+	})
+
+	return backups, nil
+}
+
+// pruneBackups removes all but the most recent backups, unless the
+// number of the backups is not limited.
+func (w *Workspace) pruneBackups() error {
+	if w.backupLimit == 0 {
+		return nil
+	}
+
+	backups, err := listBackups(w.directory, w.filename())
+	if err != nil {
+		return err
+	}
+
+	if len(backups) <= w.backupLimit {
+		return nil
+	}
+
+	for _, b := range backups[w.backupLimit:] {
+		if err := os.Remove(b.path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeTempFile writes a new temporary file in the directory and syncs
+// it to the disk. It returns the file path. On failure, the file is
+// removed.
+func writeTempFile(dir, name string, write func(f io.Writer) error) (string, error) {
+	f, err := os.CreateTemp(dir, "."+name+".tmp.*")
+	if err != nil {
+		return "", err
+	}
+
+	err = write(f)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+// link makes a hard link, replaced in tests.
+var link = os.Link
+
+// linkBackup makes the backup a hard link to the file, so the file
+// stays in place until it is replaced. The file is copied on the
+// filesystems without hard links. A backup made within the same second
+// is overwritten.
+func linkBackup(path, backupPath string) error {
+	if err := os.Remove(backupPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := link(path, backupPath); err == nil {
+		return nil
+	}
+
+	return copyFile(path, backupPath)
+}
+
+// copyFile copies the file to a new one, synced to the disk. On
+// failure, the new file is removed.
+func copyFile(path, copyPath string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(copyPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(dst, src)
+	if err == nil {
+		err = dst.Sync()
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(copyPath)
+		return err
+	}
+
+	return nil
+}
+
+// Dirty reports whether the tree, the collapsed flags of its items or
+// the marks have changed since the workspace was loaded or saved. The
+// rest of the view state, such as the cursor, does not count.
+func (w *Workspace) Dirty() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.dirty
+}
+
+// markDirty records a change which is saved but not undoable.
+func (w *Workspace) markDirty() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.dirty = true
 }
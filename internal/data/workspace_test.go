@@ -0,0 +1,883 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestWorkspaceSave(t *testing.T) {
+	t.Run("BackupsEnabled", func(t *testing.T) {
+		dir := t.TempDir()
+
+		w, err := data.LoadWorkspace(dir, nil)
+		require.NoError(t, err)
+		require.Empty(t, listBackups(t, dir))
+
+		require.NoError(t, w.Save())
+		assert.Len(t, listBackups(t, dir), 1)
+	})
+
+	t.Run("BackupsDisabled", func(t *testing.T) {
+		dir := t.TempDir()
+
+		w, err := data.LoadWorkspace(dir, nil)
+		require.NoError(t, err)
+
+		w.SetBackups(false)
+		w.Cursor().SetTitle("Updated")
+		require.NoError(t, w.Save())
+		assert.Empty(t, listBackups(t, dir))
+
+		w, err = data.LoadWorkspace(dir, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Updated", w.Cursor().Title())
+	})
+
+	t.Run("PruneBackups", func(t *testing.T) {
+		dir := t.TempDir()
+
+		w, err := data.LoadWorkspace(dir, nil)
+		require.NoError(t, err)
+
+		// the modification times go in the reverse order of the
+		// timestamps, so only the names tell which backups are recent
+		mtime := time.Now()
+		for ts := 1000; ts < 1015; ts++ {
+			p := filepath.Join(dir, fmt.Sprintf("workspace.xml.bak.%d", ts))
+			require.NoError(t, os.WriteFile(p, nil, 0o644))
+			require.NoError(t, os.Chtimes(p, mtime, mtime))
+			mtime = mtime.Add(-time.Hour)
+		}
+
+		notes := filepath.Join(dir, "workspace.xml.bak.notes")
+		require.NoError(t, os.WriteFile(notes, nil, 0o644))
+
+		require.NoError(t, w.Save())
+
+		backups := listBackups(t, dir)
+		assert.Len(t, backups, 11)
+		assert.Contains(t, backups, notes)
+		// the backup made by Save is the newest one
+		for ts := 1000; ts < 1015; ts++ {
+			p := filepath.Join(dir, fmt.Sprintf("workspace.xml.bak.%d", ts))
+			if ts < 1006 {
+				assert.NotContains(t, backups, p)
+			} else {
+				assert.Contains(t, backups, p)
+			}
+		}
+		assert.FileExists(t, filepath.Join(dir, "workspace.xml"))
+	})
+
+	t.Run("NoBackupLimit", func(t *testing.T) {
+		dir := t.TempDir()
+
+		w, err := data.LoadWorkspace(dir, nil)
+		require.NoError(t, err)
+
+		for ts := 1000; ts < 1015; ts++ {
+			p := filepath.Join(dir, fmt.Sprintf("workspace.xml.bak.%d", ts))
+			require.NoError(t, os.WriteFile(p, nil, 0o644))
+		}
+
+		w.SetBackupLimit(0)
+		require.NoError(t, w.Save())
+		assert.Len(t, listBackups(t, dir), 16)
+	})
+}
+
+func TestWorkspaceSaveAtomic(t *testing.T) {
+	t.Run("WriteFailure", func(t *testing.T) {
+		w, _ := newSavedWorkspace(t)
+		dir := w.Directory()
+
+		p := filepath.Join(dir, "workspace.xml")
+		before, err := os.ReadFile(p)
+		require.NoError(t, err)
+		backups := listBackups(t, dir)
+
+		w.Root().Head().SetTitle("Lost")
+		err = w.SaveWith(func(f io.Writer) error {
+			if _, err := f.Write(before[:len(before)/2]); err != nil {
+				return err
+			}
+			return errors.New("disk full")
+		})
+		assert.EqualError(t, err, "disk full")
+		assert.True(t, w.Dirty())
+
+		after, err := os.ReadFile(p)
+		require.NoError(t, err)
+		assert.Equal(t, before, after)
+		assert.Equal(t, backups, listBackups(t, dir))
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		for _, e := range entries {
+			assert.NotContains(t, e.Name(), ".tmp.")
+		}
+	})
+
+	t.Run("BackupKeepsPreviousContent", func(t *testing.T) {
+		w, _ := newSavedWorkspace(t)
+		dir := w.Directory()
+
+		for _, b := range listBackups(t, dir) {
+			require.NoError(t, os.Remove(b))
+		}
+
+		p := filepath.Join(dir, "workspace.xml")
+		before, err := os.ReadFile(p)
+		require.NoError(t, err)
+
+		w.Root().Head().SetTitle("Renamed")
+		require.NoError(t, w.Save())
+
+		backups := listBackups(t, dir)
+		require.Len(t, backups, 1)
+
+		backup, err := os.ReadFile(backups[0])
+		require.NoError(t, err)
+		assert.Equal(t, before, backup)
+
+		after, err := os.ReadFile(p)
+		require.NoError(t, err)
+		assert.Contains(t, string(after), "Renamed")
+	})
+
+	t.Run("BackupWithoutHardLinks", func(t *testing.T) {
+		w, _ := newSavedWorkspace(t)
+		dir := w.Directory()
+		data.DisableHardLinks(t)
+
+		for _, b := range listBackups(t, dir) {
+			require.NoError(t, os.Remove(b))
+		}
+
+		p := filepath.Join(dir, "workspace.xml")
+		before, err := os.ReadFile(p)
+		require.NoError(t, err)
+
+		// the file is copied instead
+		w.Root().Head().SetTitle("Renamed")
+		require.NoError(t, w.Save())
+
+		backups := listBackups(t, dir)
+		require.Len(t, backups, 1)
+
+		backup, err := os.ReadFile(backups[0])
+		require.NoError(t, err)
+		assert.Equal(t, before, backup)
+	})
+}
+
+func TestWorkspaceCompress(t *testing.T) {
+	w, items := newSavedWorkspace(t)
+	child := w.NewItem("Child")
+	items[1].Append(child)
+	child.SetStatus(data.StatusToDo)
+	child.SetNote("Some note")
+	items[2].SetCollapsed(true, false)
+
+	plain, err := xml.MarshalIndent(w, "", "  ")
+	require.NoError(t, err)
+
+	p := filepath.Join(w.Directory(), "workspace.xml")
+
+	t.Run("Compressed", func(t *testing.T) {
+		w.SetCompress(true)
+		require.NoError(t, w.Save())
+
+		raw, err := os.ReadFile(p)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0x1f, 0x8b}, raw[:2])
+		assert.Less(t, len(raw), len(plain))
+
+		loaded, err := data.LoadWorkspace(w.Directory(), nil)
+		require.NoError(t, err)
+
+		reloaded, err := xml.MarshalIndent(loaded, "", "  ")
+		require.NoError(t, err)
+		assert.Equal(t, string(plain), string(reloaded))
+	})
+
+	t.Run("Plain", func(t *testing.T) {
+		w.SetCompress(false)
+		require.NoError(t, w.Save())
+
+		raw, err := os.ReadFile(p)
+		require.NoError(t, err)
+		assert.Equal(t, string(plain), string(raw))
+	})
+
+	t.Run("Encrypted", func(t *testing.T) {
+		w.SetCompress(true)
+		w.SetPassphrase("secret")
+		require.NoError(t, w.Save())
+
+		loaded, err := data.LoadWorkspace(w.Directory(), passphrase("secret"))
+		require.NoError(t, err)
+
+		reloaded, err := xml.MarshalIndent(loaded, "", "  ")
+		require.NoError(t, err)
+		assert.Equal(t, string(plain), string(reloaded))
+	})
+}
+
+func TestWorkspaceConcurrentAccess(t *testing.T) {
+	w, items := newSavedWorkspace(t)
+
+	// run with -race to check the workspace state is guarded
+	done := make(chan error)
+	go func() {
+		for range 10 {
+			if err := w.Save(); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	for idx := range 100 {
+		w.NewItem("Detached")
+		w.SetCursor(items[idx%len(items)])
+		w.SetTop(w.Cursor())
+		w.Dirty()
+	}
+
+	require.NoError(t, <-done)
+}
+
+func TestWorkspaceConcurrentGetByID(t *testing.T) {
+	w, items := newSavedWorkspace(t)
+
+	// run with -race to check the index updates of the moves are
+	// guarded
+	started, stop, done := make(chan struct{}), make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		close(started)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				w.GetByID(items[0].ID())
+			}
+		}
+	}()
+
+	<-started
+	for range 100 {
+		item := w.NewItem("Moved")
+		w.Root().Append(item)
+		item.Detach()
+	}
+
+	close(stop)
+	<-done
+}
+
+func TestWorkspaceChangedOnDisk(t *testing.T) {
+	w, items := newSavedWorkspace(t)
+	assert.False(t, w.ChangedOnDisk())
+
+	items[0].SetTitle("Renamed")
+	require.NoError(t, w.Save())
+	assert.False(t, w.ChangedOnDisk())
+
+	// saved by another instance
+	other, err := data.LoadWorkspace(w.Directory(), nil)
+	require.NoError(t, err)
+	other.Root().Head().SetTitle("Changed elsewhere")
+	require.NoError(t, other.Save())
+	require.NoError(t, os.Chtimes(w.Path(), time.Time{}, time.Now().Add(time.Minute)))
+	assert.True(t, w.ChangedOnDisk())
+
+	w.IgnoreDiskChanges()
+	assert.False(t, w.ChangedOnDisk())
+}
+
+func TestWorkspaceSaveConflict(t *testing.T) {
+	w, items := newSavedWorkspace(t)
+	items[0].SetTitle("Renamed")
+
+	// touched by someone else
+	require.NoError(t, os.Chtimes(w.Path(), time.Time{}, time.Now().Add(time.Minute)))
+	before, err := os.ReadFile(w.Path())
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, w.Save(), data.ErrChangedOnDisk)
+	assert.True(t, w.Dirty())
+
+	after, err := os.ReadFile(w.Path())
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+
+	require.NoError(t, w.ForceSave())
+	assert.False(t, w.Dirty())
+	assert.False(t, w.ChangedOnDisk())
+
+	after, err = os.ReadFile(w.Path())
+	require.NoError(t, err)
+	assert.Contains(t, string(after), "Renamed")
+}
+
+func TestWorkspaceReload(t *testing.T) {
+	w, _ := newSavedWorkspace(t)
+	w.SetBackups(false)
+	w.SetCompress(true)
+	require.NoError(t, w.Save())
+
+	other, err := data.LoadWorkspace(w.Directory(), nil)
+	require.NoError(t, err)
+	other.Root().Head().SetTitle("Changed elsewhere")
+	require.NoError(t, other.Save())
+
+	reloaded, err := w.Reload()
+	require.NoError(t, err)
+	assert.Equal(t, "Changed elsewhere", reloaded.Root().Head().Title())
+	assert.False(t, reloaded.Dirty())
+	assert.False(t, reloaded.ChangedOnDisk())
+
+	// the settings are kept
+	backups := listBackups(t, w.Directory())
+	require.NoError(t, reloaded.Save())
+	assert.Equal(t, backups, listBackups(t, w.Directory()))
+
+	raw, err := os.ReadFile(w.Path())
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x1f, 0x8b}, raw[:2])
+}
+
+func TestWorkspaceDocuments(t *testing.T) {
+	t.Run("LoadAndSave", func(t *testing.T) {
+		dir := t.TempDir()
+
+		w, err := data.LoadDocument(dir, "work", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "work", w.Document())
+		assert.Equal(t, filepath.Join(dir, "work.xml"), w.Path())
+
+		item := w.NewItem("Report")
+		w.Root().Append(item)
+		w.SetCursor(item)
+		require.NoError(t, w.Save())
+		require.NoError(t, w.Save())
+
+		loaded, err := data.LoadDocument(dir, "work", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Report", loaded.Cursor().Title())
+		assert.Equal(t, item.ID(), loaded.Cursor().ID())
+
+		// the backups are kept per document
+		backups, err := filepath.Glob(filepath.Join(dir, "work.xml.bak.*"))
+		require.NoError(t, err)
+		assert.Len(t, backups, 1)
+		assert.Empty(t, listBackups(t, dir))
+
+		_, err = data.LoadWorkspace(dir, nil)
+		require.NoError(t, err)
+
+		docs, err := data.ListDocuments(dir)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"work", data.DefaultDocument}, docs)
+	})
+
+	t.Run("Open", func(t *testing.T) {
+		w, _ := newSavedWorkspace(t)
+		w.SetBackups(false)
+
+		other, err := w.Open("personal")
+		require.NoError(t, err)
+		assert.Equal(t, "personal", other.Document())
+		assert.Equal(t, "", other.Cursor().Title())
+
+		// the settings are kept
+		require.NoError(t, other.Save())
+		backups, err := filepath.Glob(filepath.Join(w.Directory(), "personal.xml.bak.*"))
+		require.NoError(t, err)
+		assert.Empty(t, backups)
+
+		same, err := other.Open(data.DefaultDocument)
+		require.NoError(t, err)
+		assert.Equal(t, "A", same.Cursor().Title())
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		dir := t.TempDir()
+
+		for _, name := range []string{"", ".hidden", "../escape", `sub\dir`} {
+			_, err := data.LoadDocument(dir, name, nil)
+			assert.Error(t, err, name)
+		}
+	})
+}
+
+func TestWorkspaceTop(t *testing.T) {
+	t.Run("Unset", func(t *testing.T) {
+		w, _ := newSavedWorkspace(t)
+		assert.Nil(t, w.Top())
+
+		w = reloadWorkspace(t, w)
+		assert.Nil(t, w.Top())
+	})
+
+	t.Run("Restored", func(t *testing.T) {
+		w, items := newSavedWorkspace(t)
+		w.SetTop(items[1])
+
+		w = reloadWorkspace(t, w)
+		require.NotNil(t, w.Top())
+		assert.Equal(t, items[1].Title(), w.Top().Title())
+	})
+
+	t.Run("MissingItem", func(t *testing.T) {
+		w, items := newSavedWorkspace(t)
+		w.SetTop(items[1])
+		items[1].Detach()
+
+		assert.Nil(t, w.Top())
+
+		w = reloadWorkspace(t, w)
+		assert.Nil(t, w.Top())
+	})
+
+	t.Run("OutsideOfRoot", func(t *testing.T) {
+		w, items := newSavedWorkspace(t)
+		w.SetTop(items[0])
+		w.SetRoot(items[1])
+
+		assert.Nil(t, w.Top())
+	})
+}
+
+func TestWorkspaceLoad(t *testing.T) {
+	t.Run("RootAndCursor", func(t *testing.T) {
+		w, items := newSavedWorkspace(t)
+		nested := w.NewItem("Nested")
+		items[1].Append(nested)
+
+		w.SetRoot(items[1])
+		w.SetCursor(nested)
+
+		w = reloadWorkspace(t, w)
+		require.NotNil(t, w.Root())
+		require.NotNil(t, w.Cursor())
+		assert.Equal(t, items[1].ID(), w.Root().ID())
+		assert.Equal(t, nested.ID(), w.Cursor().ID())
+		assert.Equal(t, "Nested", w.Cursor().Title())
+		assert.Same(t, w.Root(), w.Cursor().Parent())
+	})
+
+	t.Run("MissingItems", func(t *testing.T) {
+		w, items := newSavedWorkspace(t)
+		nested := w.NewItem("Nested")
+		items[1].Append(nested)
+
+		w.SetRoot(items[1])
+		w.SetCursor(nested)
+		require.NoError(t, w.Save())
+
+		p := filepath.Join(w.Directory(), "workspace.xml")
+		raw, err := os.ReadFile(p)
+		require.NoError(t, err)
+
+		// point the workspace attributes, which come first, to unknown ids
+		raw = bytes.Replace(raw, []byte(nested.ID().String()), []byte(uuid.NewString()), 1)
+		raw = bytes.Replace(raw, []byte(items[1].ID().String()), []byte(uuid.NewString()), 1)
+		require.NoError(t, os.WriteFile(p, raw, 0600))
+
+		w, err = data.LoadWorkspace(w.Directory(), nil)
+		require.NoError(t, err)
+
+		require.NotNil(t, w.Root())
+		assert.Nil(t, w.Root().Parent())
+		assert.Same(t, w.Root(), w.Cursor())
+	})
+}
+
+func TestWorkspaceDirty(t *testing.T) {
+	w, _ := newSavedWorkspace(t)
+	assert.False(t, w.Dirty())
+
+	w = reloadWorkspace(t, w)
+	assert.False(t, w.Dirty())
+
+	w.Root().Head().SetTitle("Renamed")
+	assert.True(t, w.Dirty())
+
+	require.NoError(t, w.Save())
+	assert.False(t, w.Dirty())
+
+	w.Undo()
+	assert.True(t, w.Dirty())
+
+	// the cursor moves do not count
+	require.NoError(t, w.Save())
+	w.SetCursor(w.Root().Tail())
+	assert.False(t, w.Dirty())
+}
+
+func TestWorkspaceDirtyMutations(t *testing.T) {
+	tests := []struct {
+		name      string
+		collapsed bool
+		mutate    func(w *data.Workspace, a, b, c *data.Item)
+	}{
+		{"SetTitle", false, func(w *data.Workspace, a, b, c *data.Item) { a.SetTitle("Renamed") }},
+		{"SetNote", false, func(w *data.Workspace, a, b, c *data.Item) { a.SetNote("Note") }},
+		{"SetStatus", false, func(w *data.Workspace, a, b, c *data.Item) { a.SetStatus(data.StatusToDo) }},
+		{"Move", false, func(w *data.Workspace, a, b, c *data.Item) { c.MoveAbove(a) }},
+		{"Demote", false, func(w *data.Workspace, a, b, c *data.Item) { b.Demote() }},
+		{"Detach", false, func(w *data.Workspace, a, b, c *data.Item) { b.Detach() }},
+		{"SetMark", false, func(w *data.Workspace, a, b, c *data.Item) { w.SetMark('a', a) }},
+		{"SetCollapsed", false, func(w *data.Workspace, a, b, c *data.Item) { a.SetCollapsed(true, false) }},
+		{"Expand", true, func(w *data.Workspace, a, b, c *data.Item) { a.SetCollapsed(false, false) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, items := newSavedWorkspace(t)
+			a, b, c := items[0], items[1], items[2]
+
+			// an item with children, so it can be collapsed
+			a.Append(w.NewItem("Child"))
+			a.SetCollapsed(tt.collapsed, false)
+			require.NoError(t, w.Save())
+			require.False(t, w.Dirty())
+
+			tt.mutate(w, a, b, c)
+			assert.True(t, w.Dirty())
+		})
+	}
+}
+
+// newSavedWorkspace returns a workspace saved to a temporary directory
+// with the root children titled "A", "B" and "C".
+func newSavedWorkspace(t *testing.T) (*data.Workspace, []*data.Item) {
+	t.Helper()
+
+	w, err := data.LoadWorkspace(t.TempDir(), nil)
+	require.NoError(t, err)
+
+	items := []*data.Item{w.Cursor()}
+	items[0].SetTitle("A")
+	for _, title := range []string{"B", "C"} {
+		item := w.NewItem(title)
+		w.Root().Append(item)
+		items = append(items, item)
+	}
+
+	require.NoError(t, w.Save())
+
+	return w, items
+}
+
+// reloadWorkspace saves the workspace and loads it again.
+func reloadWorkspace(t *testing.T, w *data.Workspace) *data.Workspace {
+	t.Helper()
+
+	require.NoError(t, w.Save())
+
+	w, err := data.LoadWorkspace(w.Directory(), nil)
+	require.NoError(t, err)
+
+	return w
+}
+
+func listBackups(t *testing.T, dir string) []string {
+	t.Helper()
+
+	backups, err := filepath.Glob(filepath.Join(dir, "workspace.xml.bak.*"))
+	require.NoError(t, err)
+
+	return backups
+}
+
+func TestWorkspaceInbox(t *testing.T) {
+	w, a, b, _ := newTestItems()
+	root := w.Root()
+	root.Append(a)
+	a.Append(b)
+	w.SetRoot(a)
+
+	inbox := w.Inbox("Inbox")
+	assert.Equal(t, "Inbox", inbox.Title())
+	assert.Same(t, a, inbox.Prev())
+	assert.Same(t, root, inbox.Parent())
+
+	assert.Same(t, inbox, w.Inbox("Inbox"))
+	assert.Same(t, root, w.Inbox(""))
+}
+
+func TestWorkspaceMigrate(t *testing.T) {
+	t.Run("Version1", func(t *testing.T) {
+		dir := t.TempDir()
+		v1, err := os.ReadFile(filepath.Join("testdata", "workspace-v1.xml"))
+		require.NoError(t, err)
+		p := filepath.Join(dir, "workspace.xml")
+		require.NoError(t, os.WriteFile(p, v1, 0600))
+
+		w, err := data.LoadWorkspace(dir, nil)
+		require.NoError(t, err)
+		assert.False(t, w.Dirty())
+
+		root := w.Root()
+		assert.Equal(t, "Home", root.Title())
+		assert.Equal(t, "5d1e4f3a-8c1b-4c6e-9f0a-2b7d3e6a1c00", root.ID().String())
+
+		groceries := w.Cursor()
+		assert.Equal(t, "Groceries", groceries.Title())
+		assert.Equal(t, data.StatusToDo, groceries.Status())
+
+		milk := groceries.Head()
+		require.NotNil(t, milk)
+		assert.Equal(t, "Milk & bread", milk.Title())
+		assert.NotEqual(t, uuid.Nil, milk.ID())
+		assert.NotEqual(t, milk.ID(), milk.Next().ID())
+		assert.Equal(t, data.StatusDone, milk.Next().Status())
+
+		projects := groceries.Next()
+		require.NotNil(t, projects)
+		assert.True(t, projects.Collapsed())
+		assert.Equal(t, "Ideas for later", projects.Note())
+		assert.Equal(t, "oli", projects.Head().Title())
+
+		// the file is upgraded and the original one kept as a backup
+		raw, err := os.ReadFile(p)
+		require.NoError(t, err)
+		assert.Contains(t, string(raw), `version="2"`)
+		assert.Contains(t, string(raw), milk.ID().String())
+
+		backups, err := filepath.Glob(filepath.Join(dir, "workspace.xml.bak.*"))
+		require.NoError(t, err)
+		require.Len(t, backups, 1)
+		backup, err := os.ReadFile(backups[0])
+		require.NoError(t, err)
+		assert.Equal(t, v1, backup)
+
+		reloaded, err := data.LoadWorkspace(dir, nil)
+		require.NoError(t, err)
+		assert.Equal(t, milk.ID(), reloaded.Cursor().Head().ID())
+	})
+
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		w, _ := newSavedWorkspace(t)
+		dir := w.Directory()
+		require.NotEmpty(t, listBackups(t, dir))
+
+		// the file of a newer version is not replaced by a backup
+		p := filepath.Join(dir, "workspace.xml")
+		raw := []byte(`<oli-workspace version="3"></oli-workspace>`)
+		require.NoError(t, os.WriteFile(p, raw, 0600))
+
+		_, err := data.LoadWorkspace(dir, nil)
+		assert.ErrorContains(t, err, "unsupported storage version 3")
+
+		saved, err := os.ReadFile(p)
+		require.NoError(t, err)
+		assert.Equal(t, raw, saved)
+	})
+}
+
+func TestWorkspaceRestoreBackup(t *testing.T) {
+	t.Run("TruncatedFile", func(t *testing.T) {
+		w, items := newSavedWorkspace(t)
+		dir := w.Directory()
+		p := filepath.Join(dir, "workspace.xml")
+
+		// replace the backups made by the saves
+		saved, err := filepath.Glob(filepath.Join(dir, "workspace.xml.bak.*"))
+		require.NoError(t, err)
+		for _, b := range saved {
+			require.NoError(t, os.Remove(b))
+		}
+
+		raw, err := os.ReadFile(p)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "workspace.xml.bak.100"), raw, 0600))
+
+		// an older backup is not loaded, nor a newer corrupt one
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "workspace.xml.bak.50"), []byte(`<oli-workspace version="2"></oli-workspace>`), 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "workspace.xml.bak.200"), raw[:len(raw)/2], 0600))
+		require.NoError(t, os.WriteFile(p, raw[:len(raw)/2], 0600))
+
+		restored, err := data.LoadWorkspace(dir, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "workspace.xml.bak.100", restored.RestoredFrom())
+		assert.True(t, restored.Dirty())
+
+		root := restored.Root()
+		require.NotNil(t, root.Head())
+		assert.Equal(t, items[0].ID(), root.Head().ID())
+		assert.Equal(t, items[0].Title(), root.Head().Title())
+		assert.Equal(t, items[len(items)-1].ID(), root.Tail().ID())
+	})
+
+	t.Run("EmptyFile", func(t *testing.T) {
+		w, items := newSavedWorkspace(t)
+		dir := w.Directory()
+		require.NotEmpty(t, listBackups(t, dir))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "workspace.xml"), nil, 0600))
+
+		restored, err := data.LoadWorkspace(dir, nil)
+		require.NoError(t, err)
+		assert.NotEmpty(t, restored.RestoredFrom())
+		assert.True(t, restored.Dirty())
+		require.NotNil(t, restored.Root().Head())
+		assert.Equal(t, items[0].ID(), restored.Root().Head().ID())
+	})
+
+	t.Run("NoValidBackup", func(t *testing.T) {
+		dir := t.TempDir()
+		p := filepath.Join(dir, "workspace.xml")
+		require.NoError(t, os.WriteFile(p, []byte(`<oli-workspace version="2"><item`), 0600))
+		require.NoError(t, os.WriteFile(p+".bak.100", []byte(`<oli-workspace`), 0600))
+
+		_, err := data.LoadWorkspace(dir, nil)
+		assert.ErrorContains(t, err, "no backup could be restored")
+	})
+
+	t.Run("ValidFile", func(t *testing.T) {
+		w, _ := newSavedWorkspace(t)
+
+		loaded, err := data.LoadWorkspace(w.Directory(), nil)
+		require.NoError(t, err)
+		assert.Empty(t, loaded.RestoredFrom())
+		assert.False(t, loaded.Dirty())
+	})
+}
+
+func TestWorkspaceGetByID(t *testing.T) {
+	w, a, b, c := newTestItems()
+	a.Append(b)
+
+	// the items are resolved once attached
+	_, ok := w.GetByID(b.ID())
+	assert.False(t, ok)
+
+	w.Root().Append(a)
+	item, ok := w.GetByID(b.ID())
+	require.True(t, ok)
+	assert.Same(t, b, item)
+
+	b.Detach()
+	_, ok = w.GetByID(b.ID())
+	assert.False(t, ok)
+
+	w.Root().Append(b)
+	item, ok = w.GetByID(b.ID())
+	require.True(t, ok)
+	assert.Same(t, b, item)
+
+	_, ok = w.GetByID(uuid.New())
+	assert.False(t, ok)
+
+	t.Run("Clone", func(t *testing.T) {
+		clone := w.Root().Clone(w)
+		_, ok := w.GetByID(clone.Head().ID())
+		assert.False(t, ok)
+	})
+
+	t.Run("Undo", func(t *testing.T) {
+		w.Root().Append(c)
+		c.Detach()
+		_, ok := w.GetByID(c.ID())
+		require.False(t, ok)
+
+		w.Undo()
+		item, ok := w.GetByID(c.ID())
+		require.True(t, ok)
+		assert.Same(t, c, item)
+
+		w.Redo()
+		_, ok = w.GetByID(c.ID())
+		assert.False(t, ok)
+	})
+
+	t.Run("Trash", func(t *testing.T) {
+		a.Append(b)
+		a.Trash()
+		item, ok := w.GetByID(b.ID())
+		require.True(t, ok)
+		assert.Same(t, b, item)
+
+		w.EmptyTrash()
+		_, ok = w.GetByID(a.ID())
+		assert.False(t, ok)
+		_, ok = w.GetByID(b.ID())
+		assert.False(t, ok)
+
+		// emptying the trash is undoable
+		w.Undo()
+		item, ok = w.GetByID(b.ID())
+		require.True(t, ok)
+		assert.Same(t, b, item)
+	})
+
+	t.Run("Load", func(t *testing.T) {
+		w, items := newSavedWorkspace(t)
+		items[1].Trash()
+
+		loaded := reloadWorkspace(t, w)
+		for _, i := range items {
+			item, ok := loaded.GetByID(i.ID())
+			require.True(t, ok)
+			assert.Equal(t, i.Title(), item.Title())
+		}
+	})
+}
+
+func BenchmarkWorkspaceXML(b *testing.B) {
+	b.Run("Marshal", func(b *testing.B) {
+		benchmarkTrees(b, 5, func(b *testing.B, w *data.Workspace) {
+			for b.Loop() {
+				if _, err := xml.MarshalIndent(w, "", "  "); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	})
+
+	b.Run("Unmarshal", func(b *testing.B) {
+		benchmarkTrees(b, 5, func(b *testing.B, w *data.Workspace) {
+			raw, err := xml.MarshalIndent(w, "", "  ")
+			require.NoError(b, err)
+
+			for b.Loop() {
+				if err := xml.Unmarshal(raw, data.NewWorkspace("", "Home")); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	})
+}
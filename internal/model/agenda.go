@@ -0,0 +1,117 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+// agendaMode lists the actionable items of the whole tree sorted by
+// the due date and moves the cursor to the selected one.
+type agendaMode struct {
+	*Outline
+
+	items    []*data.Item
+	selected int
+}
+
+func (m *Outline) openAgenda() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+	m.statusLine = ""
+
+	return agendaMode{Outline: m, items: m.workspace.Agenda()}, nil
+}
+
+// agendaPrefix returns the due date and the status shown before the
+// item path, aligned for all the items.
+func agendaPrefix(item *data.Item) string {
+	due := strings.Repeat(" ", len(data.DueLayout))
+	if d := item.Due(); !d.IsZero() {
+		due = d.Format(data.DueLayout)
+	}
+
+	return due + "  " + item.Status().String() + "  "
+}
+
+func (m agendaMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m.fileChanged(m)
+	case tea.WindowSizeMsg:
+		m.updateWindowSize(msg)
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEsc:
+			return m.Outline, nil
+		case tea.KeyEnter:
+			if len(m.items) == 0 {
+				return m, nil
+			}
+			return m.goTo(m.items[m.selected])
+		case tea.KeyUp:
+			m.selected = max(m.selected-1, 0)
+		case tea.KeyDown:
+			m.selected = max(min(m.selected+1, len(m.items)-1), 0)
+		}
+	}
+
+	return m, nil
+}
+
+func (m agendaMode) View() string {
+	if m.windowWidth == 0 || m.windowHeight == 0 {
+		return ""
+	}
+
+	header := "agenda"
+	if len(m.items) == 0 {
+		header += ": nothing to do"
+	}
+
+	var rows []string
+	for idx, item := range m.items {
+		prefix := agendaPrefix(item)
+		path := itemPath(item)
+
+		row := runewidth.Truncate(prefix+path+item.Title(), m.windowWidth, "...")
+		if idx == m.selected {
+			row = m.styles.paletteSelected.Render(row)
+		} else if strings.HasPrefix(row, prefix+path) {
+			row = prefix + m.styles.palettePath.Render(path) + row[len(prefix+path):]
+		}
+
+		rows = append(rows, row)
+	}
+
+	listHeight := m.windowHeight - 1
+	offset := clampOffset(m.selected-listHeight+1, len(rows), listHeight)
+	rows = rows[offset:min(offset+listHeight, len(rows))]
+
+	return lipgloss.Place(
+		m.windowWidth,
+		m.windowHeight,
+		lipgloss.Left,
+		lipgloss.Top,
+		lipgloss.JoinVertical(lipgloss.Left, append([]string{header}, rows...)...),
+	)
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestAgenda(t *testing.T) {
+	m, a, b, c := newTestOutline(t)
+	a.Append(b)
+	a.SetCollapsed(true, false)
+	b.SetStatus(data.StatusToDo)
+	c.SetStatus(data.StatusScheduled)
+	c.SetDue(time.Date(2025, 6, 14, 0, 0, 0, 0, time.Local))
+
+	model := press(m, key(tea.KeyCtrlX), runes("a"))
+	require.IsType(t, agendaMode{}, model)
+	assert.Equal(t, []*data.Item{c, b}, model.(agendaMode).items)
+
+	view := ansi.Strip(model.View())
+	assert.Contains(t, view, "2025-06-14  SCHD  Root / ChildC")
+	assert.Contains(t, view, "            TODO  Root / ChildA / ChildB")
+
+	model = press(model, key(tea.KeyDown), key(tea.KeyEnter))
+	assert.Same(t, m, model)
+	assert.Same(t, b, m.workspace.Cursor())
+	assert.Same(t, a, m.workspace.Root())
+
+	model = press(m, key(tea.KeyCtrlX), runes("a"), key(tea.KeyEsc))
+	assert.Same(t, m, model)
+	assert.Same(t, b, m.workspace.Cursor())
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// autosaveInterval is the period of the autosave checks.
+	autosaveInterval = 30 * time.Second
+
+	// autosaveIdle is the time since the last key press after which the
+	// user is not considered typing anymore.
+	autosaveIdle = 3 * time.Second
+)
+
+// autosaveMsg triggers an autosave check. Every mode handles it with
+// autosave, which schedules the next one.
+type autosaveMsg time.Time
+
+func scheduleAutosave() tea.Cmd {
+	return tea.Tick(autosaveInterval, func(t time.Time) tea.Msg {
+		return autosaveMsg(t)
+	})
+}
+
+// autosave saves the workspace if it has changed since the last save,
+// unless the user is typing, and schedules the next check. The title
+// being edited is not saved, so the edit stays a single undo step.
+func (m *Outline) autosave() tea.Cmd {
+	if !m.workspace.Dirty() || m.now().Sub(m.lastKeyAt) < autosaveIdle {
+		return scheduleAutosave()
+	}
+
+	if err := m.workspace.Save(); err != nil {
+		m.statusLine = m.renderStatusError(err.Error())
+		return scheduleAutosave()
+	}
+
+	// do not replace the mode menus and other messages
+	if m.statusLine == "" {
+		m.statusLine = m.renderStatusMessage("Autosaved")
+		m.autosaved = true
+	}
+
+	return scheduleAutosave()
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestAutosave(t *testing.T) {
+	// newAutosaveOutline returns an outline with a clock under the test
+	// control, with the last key pressed long ago.
+	newAutosaveOutline := func(t *testing.T) (*Outline, *time.Time) {
+		m, _, _, _ := newTestOutline(t)
+
+		now := time.Date(2025, 6, 14, 10, 0, 0, 0, time.Local)
+		m.now = func() time.Time { return now }
+		m.lastKeyAt = now.Add(-time.Minute)
+
+		return m, &now
+	}
+
+	workspaceFile := func(m *Outline) string {
+		return filepath.Join(m.workspace.Directory(), "workspace.xml")
+	}
+
+	t.Run("Clean", func(t *testing.T) {
+		m, _ := newAutosaveOutline(t)
+		require.NoError(t, m.workspace.Save())
+
+		_, cmd := m.Update(autosaveMsg{})
+		assert.NotNil(t, cmd)
+		assert.Empty(t, m.statusLine)
+	})
+
+	t.Run("Dirty", func(t *testing.T) {
+		m, _ := newAutosaveOutline(t)
+		m.do(setStatusAction(data.StatusDone))
+		assert.True(t, m.workspace.Dirty())
+
+		_, cmd := m.Update(autosaveMsg{})
+		assert.NotNil(t, cmd)
+		assert.False(t, m.workspace.Dirty())
+		assert.FileExists(t, workspaceFile(m))
+		assert.Contains(t, m.statusLine, "Autosaved")
+
+		// the message goes away on the next key press
+		press(m, key(tea.KeyCtrlDown))
+		assert.Empty(t, m.statusLine)
+	})
+
+	t.Run("Typing", func(t *testing.T) {
+		m, now := newAutosaveOutline(t)
+		m.do(setStatusAction(data.StatusDone))
+
+		press(m, runes("x"))
+		*now = now.Add(time.Second)
+
+		_, cmd := m.Update(autosaveMsg{})
+		assert.NotNil(t, cmd)
+		assert.True(t, m.workspace.Dirty())
+
+		*now = now.Add(autosaveIdle)
+		m.Update(autosaveMsg{})
+		assert.False(t, m.workspace.Dirty())
+	})
+
+	t.Run("OtherMode", func(t *testing.T) {
+		m, _ := newAutosaveOutline(t)
+		m.do(setStatusAction(data.StatusDone))
+
+		mode := press(m, key(tea.KeyCtrlX))
+		m.lastKeyAt = m.now().Add(-time.Minute)
+
+		_, cmd := mode.Update(autosaveMsg{})
+		assert.NotNil(t, cmd)
+		assert.False(t, m.workspace.Dirty())
+		assert.Contains(t, m.statusLine, "command:")
+	})
+
+	t.Run("Init", func(t *testing.T) {
+		m, _ := newAutosaveOutline(t)
+		assert.NotNil(t, m.Init())
+	})
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m *Outline) copyItem() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+
+	m.clipboard = m.workspace.Cursor().Clone(m.workspace)
+	m.statusLine = m.renderStatusMessage("Copied to clipboard")
+
+	return m, nil
+}
+
+func (m *Outline) cutItem() (tea.Model, tea.Cmd) {
+	cur := m.workspace.Cursor()
+
+	model, cmd := m.deleteItem(true)
+
+	// the last item under the view root is not deleted
+	if !cur.Deleted().IsZero() {
+		m.clipboard = cur
+	}
+
+	return model, cmd
+}
+
+// pasteItem inserts a copy of the clipboard subtree below the cursor,
+// so the same subtree can be pasted many times.
+func (m *Outline) pasteItem() (tea.Model, tea.Cmd) {
+	if m.clipboard == nil {
+		m.statusLine = m.renderStatusError("Clipboard is empty")
+		return m, nil
+	}
+
+	item := m.clipboard.Clone(m.workspace)
+	item.MoveBelow(m.workspace.Cursor())
+
+	return m.moveCursor(item)
+}
+
+// duplicateItem inserts a copy of the cursor subtree below it and moves
+// the cursor to the copy.
+func (m *Outline) duplicateItem() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+
+	cur := m.workspace.Cursor()
+	item := cur.Clone(m.workspace)
+	item.MoveBelow(cur)
+
+	return m.moveCursor(item)
+}
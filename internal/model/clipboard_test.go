@@ -0,0 +1,130 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestClipboard(t *testing.T) {
+	t.Run("CopyPaste", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+		child := m.workspace.NewItem("Child")
+		a.Append(child)
+		a.SetStatus(data.StatusToDo)
+
+		press(m, key(tea.KeyCtrlC), runes("w"))
+		press(m, key(tea.KeyCtrlDown), key(tea.KeyCtrlDown), key(tea.KeyCtrlC), runes("y"))
+
+		pasted := m.workspace.Cursor()
+		assert.NotSame(t, a, pasted)
+		assert.Same(t, b, pasted.Prev())
+		assert.Same(t, c, pasted.Next())
+		assert.Equal(t, "ChildA", pasted.Title())
+		assert.Equal(t, data.StatusToDo, pasted.Status())
+
+		pastedChild := pasted.Head()
+		require.NotNil(t, pastedChild)
+		assert.NotSame(t, child, pastedChild)
+
+		pasted.SetTitle("Changed")
+		pasted.SetStatus(data.StatusDone)
+		pastedChild.SetTitle("Changed child")
+
+		assert.Equal(t, "ChildA", a.Title())
+		assert.Equal(t, data.StatusToDo, a.Status())
+		assert.Equal(t, "Child", child.Title())
+		assert.Same(t, child, a.Head())
+	})
+
+	t.Run("CutPaste", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+
+		press(m, key(tea.KeyCtrlC), runes("k"))
+		assert.Equal(t, []*data.Item{a}, m.workspace.Trashed())
+		assert.Same(t, b, m.workspace.Cursor())
+
+		press(m, key(tea.KeyCtrlDown), key(tea.KeyCtrlC), runes("y"), key(tea.KeyCtrlR))
+
+		first := c.Next()
+		require.NotNil(t, first)
+		second := first.Next()
+		require.NotNil(t, second)
+		assert.Equal(t, "ChildA", first.Title())
+		assert.Equal(t, "ChildA", second.Title())
+		assert.NotSame(t, first, second)
+		assert.Same(t, second, m.workspace.Cursor())
+	})
+
+	t.Run("Undo", func(t *testing.T) {
+		m, a, b, _ := newTestOutline(t)
+
+		press(m, key(tea.KeyCtrlC), runes("k"), key(tea.KeyCtrlZ))
+		assert.Same(t, m.workspace.Root(), a.Parent())
+		assert.Same(t, b, a.Next())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+
+		press(m, key(tea.KeyCtrlC), runes("y"))
+		assert.Same(t, a, m.workspace.Cursor())
+		assert.Equal(t, 3, m.workspace.Root().ChildCount())
+		assert.Contains(t, m.statusLine, "empty")
+	})
+}
+
+func TestDuplicateItem(t *testing.T) {
+	m, a, b, _ := newTestOutline(t)
+	child := m.workspace.NewItem("Child")
+	a.Append(child)
+	a.SetStatus(data.StatusToDo)
+	a.SetCollapsed(true, false)
+
+	press(m, runes(" edited"), key(tea.KeyCtrlC), runes("u"))
+
+	dup := m.workspace.Cursor()
+	assert.NotSame(t, a, dup)
+	assert.Same(t, a, dup.Prev())
+	assert.Same(t, b, dup.Next())
+	assert.NotEqual(t, a.ID(), dup.ID())
+
+	// the title being edited is copied too
+	assert.Equal(t, "ChildA edited", dup.Title())
+	assert.Equal(t, data.StatusToDo, dup.Status())
+	assert.True(t, dup.Collapsed())
+
+	dupChild := dup.Head()
+	require.NotNil(t, dupChild)
+	assert.NotSame(t, child, dupChild)
+	assert.NotEqual(t, child.ID(), dupChild.ID())
+	assert.Equal(t, "Child", dupChild.Title())
+
+	press(m, runes("!"), key(tea.KeyCtrlDown))
+	dupChild.SetTitle("Changed child")
+	assert.Equal(t, "ChildA edited!", dup.Title())
+	assert.Equal(t, "ChildA edited", a.Title())
+	assert.Equal(t, "Child", child.Title())
+
+	press(m, key(tea.KeyCtrlZ), key(tea.KeyCtrlZ), key(tea.KeyCtrlZ))
+	assert.Nil(t, dup.Parent())
+	assert.Same(t, b, a.Next())
+}
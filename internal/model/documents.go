@@ -0,0 +1,66 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+// openDocumentPicker lists the documents of the workspace directory and
+// opens the chosen one. A name matching no document opens a new one.
+func (m *Outline) openDocumentPicker() (tea.Model, tea.Cmd) {
+	documents, err := data.ListDocuments(m.workspace.Directory())
+	if err != nil {
+		m.statusLine = m.renderStatusError(err.Error())
+		return m, nil
+	}
+
+	p := m.openPicker("open document: ", documents, (*Outline).switchDocument)
+	p.newHint = "new document"
+
+	return p, nil
+}
+
+// switchDocument saves the current document and replaces it with the
+// named one, which keeps its own cursor, root and zoom.
+func (m *Outline) switchDocument(name string) (tea.Model, tea.Cmd) {
+	if name == m.workspace.Document() {
+		return m, nil
+	}
+
+	m.saveCurrentTitle()
+	err := m.workspace.Save()
+	if errors.Is(err, data.ErrChangedOnDisk) {
+		return m.promptFileChanged(), nil
+	} else if err != nil {
+		m.statusLine = m.renderStatusError(err.Error())
+		return m, nil
+	}
+
+	w, err := m.workspace.Open(name)
+	if err != nil {
+		m.statusLine = m.renderStatusError(err.Error())
+		return m, nil
+	}
+
+	m.setWorkspace(w)
+
+	m.statusLine = m.renderStatusMessage("Opened " + name)
+	return m, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestDocumentPicker(t *testing.T) {
+	t.Run("Switch", func(t *testing.T) {
+		m, _, b, _ := newTestOutline(t)
+		m.workspace.SetRoot(b)
+		require.NoError(t, m.workspace.Save())
+
+		work, err := data.LoadDocument(m.workspace.Directory(), "work", nil)
+		require.NoError(t, err)
+		work.Cursor().SetTitle("Report")
+		require.NoError(t, work.Save())
+
+		model := press(m, key(tea.KeyCtrlX), runes("o"))
+		p, ok := model.(pickerMode)
+		require.True(t, ok)
+		assert.Equal(t, []string{"work", data.DefaultDocument}, p.matches)
+
+		model = press(model, runes("wo"), key(tea.KeyEnter))
+		assert.Same(t, m, model)
+		assert.Equal(t, "work", m.workspace.Document())
+		assert.Equal(t, "Report", m.textInput.Value())
+		assert.Contains(t, m.statusLine, "Opened work")
+
+		// the unsaved title goes with the document it was typed in
+		press(m, runes("!"))
+		press(m, key(tea.KeyCtrlX), runes("o"), runes("space"), key(tea.KeyEnter))
+		assert.Equal(t, data.DefaultDocument, m.workspace.Document())
+		assert.Equal(t, "ChildA", m.textInput.Value())
+		assert.Equal(t, b.ID(), m.workspace.Root().ID())
+
+		work, err = data.LoadDocument(m.workspace.Directory(), "work", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Report!", work.Cursor().Title())
+	})
+
+	t.Run("New", func(t *testing.T) {
+		m, _, _, _ := newTestOutline(t)
+
+		model := press(m, key(tea.KeyCtrlX), runes("o"), runes("personal"))
+		assert.Empty(t, model.(pickerMode).matches)
+		assert.Contains(t, ansi.Strip(model.View()), "[enter] new document")
+
+		press(model, key(tea.KeyEnter))
+		assert.Equal(t, "personal", m.workspace.Document())
+		assert.FileExists(t, m.workspace.Path())
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+
+		model := press(m, key(tea.KeyCtrlX), runes("o"), key(tea.KeyEsc))
+		assert.Same(t, m, model)
+		assert.Same(t, a, m.workspace.Cursor())
+	})
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+const (
+	dueGroupOverdue = "Overdue"
+	dueGroupToday   = "Today"
+	dueDayLayout    = "Mon " + data.DueLayout
+)
+
+type dueGroup struct {
+	label string
+	items []*data.Item
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// groupByDueDay splits the items sorted by due date into groups of
+// the same day. Items due before today go to the leading "Overdue"
+// group.
+func groupByDueDay(items []*data.Item, now time.Time) []dueGroup {
+	today := startOfDay(now)
+
+	var groups []dueGroup
+	for _, item := range items {
+		var label string
+		switch due := item.Due(); {
+		case item.IsOverdue(now):
+			label = dueGroupOverdue
+		case due.Equal(today):
+			label = dueGroupToday
+		default:
+			label = due.Format(dueDayLayout)
+		}
+
+		if n := len(groups); n > 0 && groups[n-1].label == label {
+			groups[n-1].items = append(groups[n-1].items, item)
+		} else {
+			groups = append(groups, dueGroup{label: label, items: []*data.Item{item}})
+		}
+	}
+
+	return groups
+}
+
+// promptSchedule asks for the due date of the cursor item and makes
+// it scheduled. An empty date keeps the current due date.
+func (m *Outline) promptSchedule() (tea.Model, tea.Cmd) {
+	return m.prompt("scheduled for: ", func(m *Outline, value string) (tea.Model, tea.Cmd) {
+		if strings.TrimSpace(value) == "" {
+			return m.do(scheduleAction(m.workspace.Cursor().Due()))
+		}
+
+		due, err := data.ParseDue(value, m.now())
+		if err != nil {
+			m.statusLine = m.renderStatusError(err.Error())
+			return m, nil
+		}
+
+		return m.do(scheduleAction(due))
+	})
+}
+
+func (m *Outline) schedule(due time.Time) (tea.Model, tea.Cmd) {
+	if !due.IsZero() {
+		for _, item := range m.targets(false) {
+			item.SetDue(due)
+		}
+	}
+
+	return m.setStatus(data.StatusScheduled)
+}
+
+// dueMode lists the items due within the configured number of days
+// grouped by day and zooms to the selected one.
+type dueMode struct {
+	*Outline
+
+	groups   []dueGroup
+	items    []*data.Item
+	selected int
+}
+
+func (m *Outline) openDueView() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+	m.statusLine = ""
+
+	now := m.now()
+	horizon := time.Duration(m.config.DueDays) * 24 * time.Hour
+
+	d := dueMode{Outline: m}
+	d.items = m.workspace.DueWithin(horizon, now)
+	d.groups = groupByDueDay(d.items, now)
+
+	return d, nil
+}
+
+func (m dueMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m.fileChanged(m)
+	case tea.WindowSizeMsg:
+		m.updateWindowSize(msg)
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEsc:
+			return m.Outline, nil
+		case tea.KeyEnter:
+			if len(m.items) == 0 {
+				return m, nil
+			}
+			return m.goTo(m.items[m.selected])
+		case tea.KeyUp:
+			m.selected = max(m.selected-1, 0)
+		case tea.KeyDown:
+			m.selected = max(min(m.selected+1, len(m.items)-1), 0)
+		}
+	}
+
+	return m, nil
+}
+
+func (m dueMode) View() string {
+	if m.windowWidth == 0 || m.windowHeight == 0 {
+		return ""
+	}
+
+	header := fmt.Sprintf("due within %d days", m.config.DueDays)
+	if len(m.items) == 0 {
+		header += ": nothing"
+	}
+
+	var rows []string
+	selectedRow := 0
+	idx := 0
+	for _, g := range m.groups {
+		rows = append(rows, m.styles.dueGroup.Render(g.label))
+
+		for _, item := range g.items {
+			path := itemPath(item)
+
+			row := runewidth.Truncate("  "+path+item.Title(), m.windowWidth, "...")
+			if idx == m.selected {
+				row = m.styles.paletteSelected.Render(row)
+				selectedRow = len(rows)
+			}
+
+			rows = append(rows, row)
+			idx++
+		}
+	}
+
+	listHeight := m.windowHeight - 1
+	offset := clampOffset(selectedRow-listHeight+1, len(rows), listHeight)
+	rows = rows[offset:min(offset+listHeight, len(rows))]
+
+	return lipgloss.Place(
+		m.windowWidth,
+		m.windowHeight,
+		lipgloss.Left,
+		lipgloss.Top,
+		lipgloss.JoinVertical(lipgloss.Left, append([]string{header}, rows...)...),
+	)
+}
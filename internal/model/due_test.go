@@ -0,0 +1,138 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestGroupByDueDay(t *testing.T) {
+	now := time.Date(2025, 6, 14, 10, 0, 0, 0, time.Local)
+	day := 24 * time.Hour
+
+	m, a, b, c := newTestOutline(t)
+	d := m.workspace.NewItem("ChildD")
+	m.workspace.Root().Append(d)
+
+	a.SetDue(now.Add(-3 * day))
+	b.SetDue(now.Add(-1 * day))
+	c.SetDue(now)
+	d.SetDue(now.Add(2 * day))
+
+	groups := groupByDueDay(m.workspace.DueWithin(7*day, now), now)
+	require.Len(t, groups, 3)
+
+	assert.Equal(t, dueGroupOverdue, groups[0].label)
+	assert.Equal(t, []*data.Item{a, b}, groups[0].items)
+
+	assert.Equal(t, dueGroupToday, groups[1].label)
+	assert.Equal(t, []*data.Item{c}, groups[1].items)
+
+	assert.Equal(t, "Mon 2025-06-16", groups[2].label)
+	assert.Equal(t, []*data.Item{d}, groups[2].items)
+}
+
+func TestDueView(t *testing.T) {
+	now := time.Date(2025, 6, 14, 10, 0, 0, 0, time.Local)
+
+	m, a, b, c := newTestOutline(t)
+	m.now = func() time.Time { return now }
+
+	a.Append(b)
+	b.SetDue(now.Add(24 * time.Hour))
+	c.SetDue(now.Add(30 * 24 * time.Hour))
+
+	model := press(m, key(tea.KeyCtrlX), runes("d"))
+	require.IsType(t, dueMode{}, model)
+	assert.Equal(t, []*data.Item{b}, model.(dueMode).items)
+
+	press(model, key(tea.KeyEnter))
+	assert.Same(t, a, m.workspace.Root())
+	assert.Same(t, b, m.workspace.Cursor())
+}
+
+func TestSchedule(t *testing.T) {
+	now := time.Date(2025, 6, 14, 10, 0, 0, 0, time.Local)
+
+	t.Run("WithDate", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+		m.now = func() time.Time { return now }
+
+		press(m, key(tea.KeyCtrlC), runes("s"), runes("s"), runes("Jul 1, 2025"), key(tea.KeyEnter))
+
+		assert.Equal(t, data.StatusScheduled, a.Status())
+		assert.Equal(t, time.Date(2025, 7, 1, 0, 0, 0, 0, time.Local), a.Due())
+		assert.Contains(t, m.renderItemEntry(a), "2025-07-01")
+
+		press(m, key(tea.KeyCtrlZ))
+		assert.Equal(t, data.StatusNone, a.Status())
+		assert.True(t, a.Due().IsZero())
+	})
+
+	t.Run("EmptyDate", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+		a.SetDue(now)
+
+		press(m, key(tea.KeyCtrlC), runes("s"), runes("s"), key(tea.KeyEnter))
+
+		assert.Equal(t, data.StatusScheduled, a.Status())
+		assert.Equal(t, time.Date(2025, 6, 14, 0, 0, 0, 0, time.Local), a.Due())
+	})
+
+	t.Run("BadDate", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+
+		model := press(m, key(tea.KeyCtrlC), runes("s"), runes("s"), runes("someday"), key(tea.KeyEnter))
+
+		assert.Same(t, m, model)
+		assert.Equal(t, data.StatusNone, a.Status())
+		assert.True(t, a.Due().IsZero())
+		assert.Contains(t, m.statusLine, "someday")
+	})
+}
+
+func TestOverdueHighlight(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI)
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+	setMonochrome(t, false)
+
+	now := time.Date(2025, 6, 14, 10, 0, 0, 0, time.Local)
+	m, _, b, _ := newTestOutline(t)
+	m.now = func() time.Time { return now }
+	overdue := m.styles.itemOverdue.Inherit(m.styles.itemNormal).Render("ChildB")
+
+	b.SetStatus(data.StatusToDo)
+	b.SetDue(now)
+	assert.NotContains(t, m.renderItemEntry(b), overdue)
+
+	b.SetDue(now.Add(-24 * time.Hour))
+	assert.Contains(t, m.renderItemEntry(b), overdue)
+
+	// the status label keeps its own color
+	assert.Contains(t, m.renderItemEntry(b), m.getStatus(b))
+
+	b.SetStatus(data.StatusDone)
+	assert.NotContains(t, m.renderItemEntry(b), overdue)
+}
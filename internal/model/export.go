@@ -0,0 +1,83 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+const markdownExportFilename = "export.md"
+
+// exportMarkdown writes the view root subtree as Markdown to a file in
+// the workspace directory, replacing the previous export.
+func (m *Outline) exportMarkdown() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+
+	var buf bytes.Buffer
+	if err := data.ExportMarkdown(m.workspace.Root(), &buf); err != nil {
+		m.statusLine = m.renderStatusError(err.Error())
+		return m, nil
+	}
+
+	p := filepath.Join(m.workspace.Directory(), markdownExportFilename)
+	if err := os.WriteFile(p, buf.Bytes(), 0600); err != nil {
+		m.statusLine = m.renderStatusError(err.Error())
+		return m, nil
+	}
+
+	m.statusLine = m.renderStatusMessage("Exported to " + p)
+	return m, nil
+}
+
+func (m *Outline) promptExportSubtree() (tea.Model, tea.Cmd) {
+	return m.prompt("export subtree as (format file): ", (*Outline).exportCursorSubtree)
+}
+
+// exportCursorSubtree writes the descendants of the cursor item to the
+// file in the format, both given in the value separated by a space.
+// Relative paths are resolved against the workspace directory.
+func (m *Outline) exportCursorSubtree(value string) (tea.Model, tea.Cmd) {
+	format, path, ok := strings.Cut(strings.TrimSpace(value), " ")
+	path = strings.TrimSpace(path)
+	if !ok || path == "" {
+		m.statusLine = m.renderStatusError("Expected a format and a file name, e.g. \"md notes.md\"")
+		return m, nil
+	}
+
+	var buf bytes.Buffer
+	if err := data.Export(format, m.workspace.Cursor(), &buf); err != nil {
+		m.statusLine = m.renderStatusError(err.Error())
+		return m, nil
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(m.workspace.Directory(), path)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		m.statusLine = m.renderStatusError(err.Error())
+		return m, nil
+	}
+
+	m.statusLine = m.renderStatusMessage("Exported to " + path)
+	return m, nil
+}
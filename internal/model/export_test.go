@@ -0,0 +1,69 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestExportMarkdown(t *testing.T) {
+	m, _, b, _ := newTestOutline(t)
+	b.SetStatus(data.StatusDone)
+
+	press(m, runes("!"), key(tea.KeyCtrlX), runes("m"))
+
+	out, err := os.ReadFile(filepath.Join(m.workspace.Directory(), "export.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "- ChildA!\n- [x] ChildB\n- ChildC\n", string(out))
+	assert.Contains(t, m.statusLine, "Exported to")
+}
+
+func TestExportCursorSubtree(t *testing.T) {
+	t.Run("Written", func(t *testing.T) {
+		m, a, b, _ := newTestOutline(t)
+		a.Append(b)
+
+		press(m, key(tea.KeyCtrlX), runes("e"), runes("text out.txt"), key(tea.KeyEnter))
+
+		out, err := os.ReadFile(filepath.Join(m.workspace.Directory(), "out.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "ChildB\n", string(out))
+	})
+
+	t.Run("UnknownFormat", func(t *testing.T) {
+		m, _, _, _ := newTestOutline(t)
+
+		press(m, key(tea.KeyCtrlX), runes("e"), runes("docx out.docx"), key(tea.KeyEnter))
+
+		assert.Contains(t, m.statusLine, "unknown export format")
+		assert.NoFileExists(t, filepath.Join(m.workspace.Directory(), "out.docx"))
+	})
+
+	t.Run("WriteError", func(t *testing.T) {
+		m, _, _, _ := newTestOutline(t)
+
+		press(m, key(tea.KeyCtrlX), runes("e"), runes("md missing/out.md"), key(tea.KeyEnter))
+
+		assert.Contains(t, m.statusLine, "no such file or directory")
+	})
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+// hiddenItem reports whether the item is hidden by the view options,
+// together with its subtree.
+func (m *Outline) hiddenItem(item *data.Item) bool {
+	if m.canceledHidden(item) {
+		return true
+	}
+
+	return len(m.statusFilter) > 0 && !m.matchesFilter(item)
+}
+
+func (m *Outline) canceledHidden(item *data.Item) bool {
+	return m.hideCanceled && item.Status() == data.StatusCanceled
+}
+
+// matchesFilter reports whether the item or any of its descendants has
+// one of the filtered statuses, so the ancestors of the matching items
+// stay displayed for context.
+func (m *Outline) matchesFilter(item *data.Item) bool {
+	if m.statusFilter[item.Status()] {
+		return true
+	}
+
+	for c := item.Head(); c != nil; c = c.Next() {
+		if !m.canceledHidden(c) && m.matchesFilter(c) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// toggleStatusFilter adds the status to the filter, or removes it if
+// it is already there.
+func (m *Outline) toggleStatusFilter(s data.Status) {
+	if m.statusFilter[s] {
+		delete(m.statusFilter, s)
+		return
+	}
+
+	if m.statusFilter == nil {
+		m.statusFilter = make(map[data.Status]bool)
+	}
+	m.statusFilter[s] = true
+}
+
+// filteredStatuses returns the statuses of the filter in their order.
+func (m *Outline) filteredStatuses() []data.Status {
+	var statuses []data.Status
+	for s := range m.statusFilter {
+		statuses = append(statuses, s)
+	}
+	slices.Sort(statuses)
+
+	return statuses
+}
+
+// renderFilterIndicator renders the filtered statuses for the
+// breadcrumbs, so the hidden items are not mistaken for the lost ones.
+// It returns an empty string if there is no filter.
+func (m *Outline) renderFilterIndicator() string {
+	statuses := m.filteredStatuses()
+	if len(statuses) == 0 {
+		return ""
+	}
+
+	names := make([]string, len(statuses))
+	for i, s := range statuses {
+		names[i] = s.String()
+	}
+
+	return m.styles.filterIndicator.Render("[filter: " + strings.Join(names, " ") + "]")
+}
+
+// isHidden reports whether the item or any of its ancestors under the
+// view root is hidden by the view options.
+func (m *Outline) isHidden(item *data.Item) bool {
+	root := m.workspace.Root()
+	for p := item; p != nil && p != root; p = p.Parent() {
+		if m.hiddenItem(p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// displayedRows returns the rows of the item list. The slice is reused
+// by the next call, so it must not be kept.
+func (m *Outline) displayedRows() []*data.Item {
+	m.rows = m.workspace.Root().AppendFiltered(m.rows[:0], m.hiddenItem)
+	return m.rows
+}
+
+// revealCursor moves the cursor off a hidden item to the nearest
+// displayed row below the hidden subtree, or above it if there is
+// none. If no rows are displayed, the cursor stays.
+func (m *Outline) revealCursor() (tea.Model, tea.Cmd) {
+	cur := m.workspace.Cursor()
+	if !m.isHidden(cur) {
+		return m, nil
+	}
+
+	// the topmost hidden ancestor starts the hidden subtree
+	top := cur
+	root := m.workspace.Root()
+	for p := cur.Parent(); p != nil && p != root; p = p.Parent() {
+		if m.hiddenItem(p) {
+			top = p
+		}
+	}
+
+	item := top.NextRow()
+	for item != nil && m.isHidden(item) {
+		item = item.NextRow()
+	}
+
+	if item == nil {
+		item = top.PrevRow()
+		for item != nil && m.isHidden(item) {
+			item = item.PrevRow()
+		}
+	}
+
+	return m.moveCursor(item)
+}
+
+// statusFilterMode toggles the statuses of the displayed items. It
+// stays active until Esc or Enter, so several statuses can be picked.
+type statusFilterMode struct {
+	*Outline
+}
+
+func (m statusFilterMode) statusLine() string {
+	return m.keys.statusFilter.menu()
+}
+
+func (m statusFilterMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m.fileChanged(m)
+	case tea.WindowSizeMsg:
+		m.updateWindowSize(msg)
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyEsc || msg.Type == tea.KeyEnter {
+			m.Outline.statusLine = ""
+			return m.Outline, nil
+		}
+
+		switch m.keys.statusFilter.match(msg) {
+		case "filterNone":
+			m.toggleStatusFilter(data.StatusNone)
+		case "filterToDo":
+			m.toggleStatusFilter(data.StatusToDo)
+		case "filterDone":
+			m.toggleStatusFilter(data.StatusDone)
+		case "filterCanceled":
+			m.toggleStatusFilter(data.StatusCanceled)
+		case "filterWaiting":
+			m.toggleStatusFilter(data.StatusWaiting)
+		case "filterScheduled":
+			m.toggleStatusFilter(data.StatusScheduled)
+		case "clearFilter":
+			m.statusFilter = nil
+		default:
+			return m, nil
+		}
+
+		m.revealCursor()
+	}
+
+	return m, nil
+}
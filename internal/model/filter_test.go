@@ -0,0 +1,129 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestHideCanceled(t *testing.T) {
+	t.Run("Filter", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+		nested := m.workspace.NewItem("Nested")
+		b.Append(nested)
+		b.SetStatus(data.StatusCanceled)
+		c.SetStatus(data.StatusDone)
+
+		assert.Equal(t, []*data.Item{a, b, nested, c}, m.displayedRows())
+
+		press(m, key(tea.KeyCtrlX), runes("v"), runes("h"))
+		assert.True(t, m.hideCanceled)
+		assert.Equal(t, []*data.Item{a, c}, m.displayedRows())
+		assert.NotContains(t, m.View(), "Nested")
+
+		// navigation skips the hidden rows
+		press(m, key(tea.KeyCtrlDown))
+		assert.Same(t, c, m.workspace.Cursor())
+		press(m, key(tea.KeyCtrlUp))
+		assert.Same(t, a, m.workspace.Cursor())
+
+		// the items are only hidden
+		assert.Equal(t, data.StatusCanceled, b.Status())
+		assert.Same(t, b, nested.Parent())
+	})
+
+	t.Run("CursorOnHiddenItem", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+		nested := m.workspace.NewItem("Nested")
+		b.Append(nested)
+		b.SetStatus(data.StatusCanceled)
+		m.moveCursor(nested)
+
+		press(m, key(tea.KeyCtrlX), runes("v"), runes("h"))
+		assert.Same(t, c, m.workspace.Cursor())
+
+		// canceling the cursor item moves the cursor off it
+		press(m, key(tea.KeyCtrlC), runes("s"), runes("c"))
+		assert.Same(t, a, m.workspace.Cursor())
+	})
+}
+
+func TestStatusFilter(t *testing.T) {
+	t.Run("Filter", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+		nested := m.workspace.NewItem("Nested")
+		deeper := m.workspace.NewItem("Deeper")
+		b.Append(nested)
+		nested.Append(deeper)
+		a.SetStatus(data.StatusToDo)
+		deeper.SetStatus(data.StatusToDo)
+		c.SetStatus(data.StatusDone)
+
+		var model tea.Model = m
+		model = press(model, key(tea.KeyCtrlX), runes("v"), runes("f"), runes("t"))
+		assert.IsType(t, statusFilterMode{}, model)
+
+		// the ancestors of the matching items are kept for context
+		assert.Equal(t, []*data.Item{a, b, nested, deeper}, m.displayedRows())
+		assert.Contains(t, m.View(), "[filter: TODO]")
+
+		model = press(model, runes("d"))
+		assert.Equal(t, []*data.Item{a, b, nested, deeper, c}, m.displayedRows())
+		assert.Contains(t, m.View(), "[filter: TODO DONE]")
+
+		model = press(model, runes("t"))
+		assert.Equal(t, []*data.Item{c}, m.displayedRows())
+		assert.Same(t, c, m.workspace.Cursor())
+
+		model = press(model, key(tea.KeyEsc))
+		assert.Same(t, m, model)
+		assert.Contains(t, m.View(), "[filter: DONE]")
+
+		press(m, key(tea.KeyCtrlX), runes("v"), runes("f"), runes("x"), key(tea.KeyEsc))
+		assert.Equal(t, []*data.Item{a, b, nested, deeper, c}, m.displayedRows())
+		assert.NotContains(t, m.View(), "filter:")
+	})
+
+	t.Run("HideCanceled", func(t *testing.T) {
+		m, a, b, _ := newTestOutline(t)
+		nested := m.workspace.NewItem("Nested")
+		b.Append(nested)
+		a.SetStatus(data.StatusToDo)
+		b.SetStatus(data.StatusCanceled)
+		nested.SetStatus(data.StatusToDo)
+
+		press(m, key(tea.KeyCtrlX), runes("v"), runes("h"))
+		press(m, key(tea.KeyCtrlX), runes("v"), runes("f"), runes("t"), key(tea.KeyEsc))
+		assert.Equal(t, []*data.Item{a}, m.displayedRows())
+	})
+
+	t.Run("StatusChange", func(t *testing.T) {
+		m, a, b, _ := newTestOutline(t)
+		a.SetStatus(data.StatusToDo)
+		b.SetStatus(data.StatusToDo)
+
+		press(m, key(tea.KeyCtrlX), runes("v"), runes("f"), runes("t"), key(tea.KeyEsc))
+
+		// the done item leaves the view, taking the cursor along
+		press(m, key(tea.KeyCtrlC), runes("s"), runes("d"))
+		assert.Equal(t, []*data.Item{b}, m.displayedRows())
+		assert.Same(t, b, m.workspace.Cursor())
+	})
+}
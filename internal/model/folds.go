@@ -0,0 +1,100 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+// foldMemorySize is the number of the subtrees whose fold states are
+// remembered.
+const foldMemorySize = 32
+
+// foldSnapshot maps the ids of the subtree items to their "collapsed"
+// flag values.
+type foldSnapshot map[uuid.UUID]bool
+
+// foldMemory keeps the fold state snapshots of the subtrees, keyed by
+// the subtree root id. When full, the least recently used snapshot is
+// dropped.
+type foldMemory struct {
+	capacity  int
+	snapshots map[uuid.UUID]foldSnapshot
+
+	// snapshot keys, the most recently used last
+	order []uuid.UUID
+}
+
+func newFoldMemory(capacity int) *foldMemory {
+	return &foldMemory{
+		capacity:  capacity,
+		snapshots: make(map[uuid.UUID]foldSnapshot),
+	}
+}
+
+// touch marks the key as the most recently used one.
+func (f *foldMemory) touch(id uuid.UUID) {
+	if idx := slices.Index(f.order, id); idx >= 0 {
+		f.order = slices.Delete(f.order, idx, idx+1)
+	}
+	f.order = append(f.order, id)
+}
+
+// save remembers the fold states of the root descendants.
+func (f *foldMemory) save(root *data.Item) {
+	snapshot := make(foldSnapshot)
+
+	var walk func(parent *data.Item)
+	walk = func(parent *data.Item) {
+		for c := parent.Head(); c != nil; c = c.Next() {
+			snapshot[c.ID()] = c.Collapsed()
+			walk(c)
+		}
+	}
+	walk(root)
+
+	f.snapshots[root.ID()] = snapshot
+	f.touch(root.ID())
+
+	if len(f.order) > f.capacity {
+		delete(f.snapshots, f.order[0])
+		f.order = f.order[1:]
+	}
+}
+
+// restore applies the remembered fold states to the root descendants.
+// The items added to the subtree since the snapshot are left as is.
+func (f *foldMemory) restore(root *data.Item) {
+	snapshot, ok := f.snapshots[root.ID()]
+	if !ok {
+		return
+	}
+	f.touch(root.ID())
+
+	var walk func(parent *data.Item)
+	walk = func(parent *data.Item) {
+		for c := parent.Head(); c != nil; c = c.Next() {
+			if collapsed, ok := snapshot[c.ID()]; ok {
+				c.SetCollapsed(collapsed, false)
+			}
+			walk(c)
+		}
+	}
+	walk(root)
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestFoldMemory(t *testing.T) {
+	t.Run("RestoredOnZoomIn", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+		a.Append(b)
+		a.Append(c)
+		b.Append(m.workspace.NewItem("NestedB"))
+		c.Append(m.workspace.NewItem("NestedC"))
+
+		press(m, key(tea.KeyCtrlC), runes("z"))
+		assert.Same(t, a, m.workspace.Root())
+
+		// fold ChildC only, then zoom out
+		press(m, key(tea.KeyCtrlDown), key(tea.KeyCtrlDown), key(tea.KeyCtrlC), runes("f"))
+		assert.True(t, c.Collapsed())
+		press(m, key(tea.KeyCtrlC), runes("Z"))
+
+		// intervening recursive unfold and fold
+		a.SetCollapsed(false, true)
+		b.SetCollapsed(true, false)
+
+		m.moveCursor(a)
+		press(m, key(tea.KeyCtrlC), runes("z"))
+		assert.False(t, b.Collapsed())
+		assert.True(t, c.Collapsed())
+	})
+
+	t.Run("OtherItem", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+		nested := m.workspace.NewItem("Nested")
+		a.Append(nested)
+		b.Append(c)
+
+		m.workspace.SetRoot(a)
+		m.moveCursor(nested)
+		press(m, key(tea.KeyCtrlC), runes("Z"))
+
+		c.Append(m.workspace.NewItem("NestedC"))
+		c.SetCollapsed(true, false)
+
+		m.moveCursor(b)
+		press(m, key(tea.KeyCtrlC), runes("z"))
+		assert.True(t, c.Collapsed())
+	})
+}
+
+func TestFoldMemoryEviction(t *testing.T) {
+	w := data.NewWorkspace("", "Root")
+	f := newFoldMemory(2)
+
+	items := make([]*data.Item, 3)
+	for idx := range items {
+		items[idx] = w.NewItem("")
+		w.Root().Append(items[idx])
+		f.save(items[idx])
+	}
+
+	assert.NotContains(t, f.snapshots, items[0].ID())
+	assert.Contains(t, f.snapshots, items[1].ID())
+	assert.Contains(t, f.snapshots, items[2].ID())
+
+	// restoring makes a snapshot the most recently used one
+	f.restore(items[1])
+	f.save(items[0])
+	assert.Contains(t, f.snapshots, items[1].ID())
+	assert.NotContains(t, f.snapshots, items[2].ID())
+}
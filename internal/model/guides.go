@@ -0,0 +1,71 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+// The guides take two columns per level, as the plain indentation does,
+// so the title width is not affected by them.
+const (
+	guideLine   = "│ " // U+2502
+	guideBranch = "├─" // U+251C U+2500
+	guideLast   = "└─" // U+2514 U+2500
+	guideBlank  = "  "
+)
+
+// hasNextSibling reports whether any of the siblings below the item is
+// displayed.
+func (m *Outline) hasNextSibling(item *data.Item) bool {
+	for s := item.Next(); s != nil; s = s.Next() {
+		if !m.hiddenItem(s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// renderGuides renders the indentation guides in place of the padding
+// of the item row taking the given number of screen lines. A line is
+// drawn for every ancestor followed by a displayed sibling, and the
+// connector of the item on its first line.
+func (m *Outline) renderGuides(item *data.Item, height int) string {
+	var ancestors string
+	root := m.workspace.Root()
+	for p := item.Parent(); p != nil && p != root; p = p.Parent() {
+		if m.hasNextSibling(p) {
+			ancestors = guideLine + ancestors
+		} else {
+			ancestors = guideBlank + ancestors
+		}
+	}
+
+	first, rest := guideLast, guideBlank
+	if m.hasNextSibling(item) {
+		first, rest = guideBranch, guideLine
+	}
+
+	lines := make([]string, max(height, 1))
+	for i := range lines {
+		lines[i] = ancestors + rest
+	}
+	lines[0] = ancestors + first
+
+	return m.styles.indentGuide.Render(strings.Join(lines, "\n"))
+}
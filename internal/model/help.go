@@ -0,0 +1,195 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// helpSection is a key section listed in the help view, along with
+// the keys leading to its mode.
+type helpSection struct {
+	section *keySection
+	prefix  string
+}
+
+func (k *keyMap) helpSections() []helpSection {
+	prefix := func(s *keySection, parent, name string) string {
+		keys := s.keys(name)
+		if len(keys) == 0 {
+			return parent
+		}
+
+		return strings.TrimSpace(parent + " " + keys[0])
+	}
+
+	command := prefix(&k.global, "", "commandMode")
+	item := prefix(&k.global, "", "itemMode")
+	view := prefix(&k.command, command, "viewMode")
+
+	return []helpSection{
+		{&k.global, ""},
+		{&k.command, command},
+		{&k.quitConfirm, prefix(&k.command, command, "quit")},
+		{&k.reloadConfirm, prefix(&k.command, command, "reload")},
+		{&k.fileChanged, ""},
+		{&k.trash, prefix(&k.command, command, "trash")},
+		{&k.search, prefix(&k.command, command, "search")},
+		{&k.statusJump, prefix(&k.command, command, "nextWithStatus")},
+		{&k.view, view},
+		{&k.statusFilter, prefix(&k.view, view, "filterMode")},
+		{&k.item, item},
+		{&k.itemStatus, prefix(&k.item, item, "statusMode")},
+		{&k.itemPriority, prefix(&k.item, item, "priorityMode")},
+	}
+}
+
+// helpLines lists every key binding grouped by mode, the keys aligned
+// in a column, rendered with the styles.
+func (k *keyMap) helpLines(st *styles) []string {
+	sections := k.helpSections()
+
+	keyColumn := func(s helpSection, b binding) string {
+		// a long run of keys, like the digits, is shown as a range
+		if len(b.keys) > 3 {
+			return strings.TrimSpace(s.prefix+" "+b.keys[0]) + "-" + b.keys[len(b.keys)-1]
+		}
+
+		keys := make([]string, len(b.keys))
+		for i, key := range b.keys {
+			keys[i] = strings.TrimSpace(s.prefix + " " + key)
+		}
+
+		return strings.Join(keys, ", ")
+	}
+
+	width := 0
+	for _, s := range sections {
+		for _, b := range s.section.bindings {
+			width = max(width, runewidth.StringWidth(keyColumn(s, b)))
+		}
+	}
+
+	var lines []string
+	for i, s := range sections {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+
+		title := s.section.title
+		if s.prefix != "" {
+			title += " (" + s.prefix + ")"
+		}
+		lines = append(lines, st.helpTitle.Render(title))
+
+		for _, b := range s.section.bindings {
+			keys := runewidth.FillRight(keyColumn(s, b), width)
+			lines = append(lines, "  "+st.helpKey.Render(keys)+"  "+b.help)
+		}
+	}
+
+	return lines
+}
+
+// helpMode shows the key bindings in a bordered panel scrolled by the
+// arrow keys. Any other key returns to the outline.
+type helpMode struct {
+	*Outline
+
+	lines  []string
+	offset int
+}
+
+func (m *Outline) openHelp() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+	m.statusLine = ""
+
+	return helpMode{Outline: m, lines: m.keys.helpLines(m.styles)}, nil
+}
+
+// pageHeight returns the number of lines fitting inside the panel.
+func (m helpMode) pageHeight() int {
+	return max(m.windowHeight-2, 1)
+}
+
+func (m helpMode) scroll(delta int) helpMode {
+	m.offset = clampOffset(m.offset+delta, len(m.lines), m.pageHeight())
+	return m
+}
+
+func (m helpMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m.fileChanged(m)
+	case tea.WindowSizeMsg:
+		m.updateWindowSize(msg)
+		return m.scroll(0), nil
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyUp:
+			return m.scroll(-1), nil
+		case tea.KeyDown:
+			return m.scroll(1), nil
+		case tea.KeyPgUp:
+			return m.scroll(-m.pageHeight()), nil
+		case tea.KeyPgDown:
+			return m.scroll(m.pageHeight()), nil
+		case tea.KeyHome:
+			return m.scroll(-len(m.lines)), nil
+		case tea.KeyEnd:
+			return m.scroll(len(m.lines)), nil
+		default:
+			return m.Outline, nil
+		}
+	}
+
+	return m, nil
+}
+
+func (m helpMode) View() string {
+	if m.windowWidth < 4 || m.windowHeight < 3 {
+		return ""
+	}
+
+	// the border takes a column on each side, the padding another one
+	width := m.windowWidth - 4
+
+	end := min(m.offset+m.pageHeight(), len(m.lines))
+	lines := make([]string, 0, end-m.offset)
+	for _, line := range m.lines[m.offset:end] {
+		lines = append(lines, truncateRendered(line, width))
+	}
+
+	return m.styles.helpPanel.
+		Width(m.windowWidth - 2).
+		Height(m.pageHeight()).
+		Render(strings.Join(lines, "\n"))
+}
+
+// truncateRendered truncates a line which might contain the style
+// escape sequences to the given width.
+func truncateRendered(line string, width int) string {
+	if lipgloss.Width(line) <= width {
+		return line
+	}
+
+	return lipgloss.NewStyle().MaxWidth(width).Render(line)
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultKeyMap(t *testing.T) {
+	k := defaultKeyMap()
+
+	for _, s := range k.helpSections() {
+		seen := make(map[string]string)
+		for _, b := range s.section.bindings {
+			for _, key := range b.keys {
+				other, dup := seen[key]
+				assert.False(t, dup, "%s: %q bound to %s and %s", s.section.title, key, other, b.name)
+				seen[key] = b.name
+			}
+		}
+	}
+
+	assert.Equal(t, "item status: [n]one  [t]odo  [d]one  [c]anceled  [w]aiting  [s]cheduled  [r]ecursive", k.itemStatus.menu())
+	assert.Contains(t, k.item.menu(), "complete [a]ncestors  toggle [c]ase")
+	assert.Contains(t, k.item.menu(), "cut [k]")
+}
+
+func TestHelpLines(t *testing.T) {
+	k := defaultKeyMap()
+	text := strings.Join(k.helpLines(newStyles(themes[defaultThemeName])), "\n")
+
+	for _, s := range k.helpSections() {
+		for _, b := range s.section.bindings {
+			assert.Contains(t, text, b.help)
+		}
+	}
+
+	assert.Contains(t, text, "item status (ctrl+c s)")
+	assert.Contains(t, text, "ctrl+x v c")
+	assert.Contains(t, text, "ctrl+c 1-9")
+}
+
+func TestHelpMode(t *testing.T) {
+	m, a, _, _ := newTestOutline(t)
+	m.Update(tea.WindowSizeMsg{Width: 40, Height: 10})
+
+	model := press(m, key(tea.KeyF1))
+	require.IsType(t, helpMode{}, model)
+
+	view := model.View()
+	assert.Equal(t, 10, lipgloss.Height(view))
+	assert.Equal(t, 40, lipgloss.Width(view))
+
+	model = press(model, key(tea.KeyEnd))
+	h := model.(helpMode)
+	assert.Equal(t, len(h.lines)-h.pageHeight(), h.offset)
+
+	model = press(model, runes("x"))
+	assert.Same(t, m, model)
+	assert.Equal(t, "ChildA", a.Title())
+
+	model = press(m, key(tea.KeyCtrlX), runes("?"))
+	require.IsType(t, helpMode{}, model)
+	assert.Empty(t, m.statusLine)
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func (m *Outline) promptImportCSV() (tea.Model, tea.Cmd) {
+	return m.prompt("import CSV: ", (*Outline).importCSV)
+}
+
+// importCSV imports the CSV file under the cursor item. Relative paths
+// are resolved against the workspace directory.
+func (m *Outline) importCSV(path string) (tea.Model, tea.Cmd) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return m, nil
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(m.workspace.Directory(), path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		m.statusLine = m.renderStatusError(err.Error())
+		return m, nil
+	}
+	defer f.Close()
+
+	cur := m.workspace.Cursor()
+	before := cur.ChildCount()
+
+	err = m.workspace.ImportCSV(f, cur)
+	imported := cur.ChildCount() - before
+	if imported > 0 {
+		cur.SetCollapsed(false, false)
+	}
+
+	var skipped *data.SkippedRowsError
+	switch {
+	case errors.As(err, &skipped):
+		m.statusLine = m.renderStatusError(fmt.Sprintf("Imported %d items, %s", imported, err))
+	case err != nil:
+		m.statusLine = m.renderStatusError(err.Error())
+	default:
+		m.statusLine = m.renderStatusMessage(fmt.Sprintf("Imported %d items", imported))
+	}
+
+	return m, nil
+}
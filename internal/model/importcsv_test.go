@@ -0,0 +1,39 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportCSV(t *testing.T) {
+	m, a, _, _ := newTestOutline(t)
+
+	in := "title,status\nFirst,todo\nSecond,unknown\n"
+	require.NoError(t, os.WriteFile(filepath.Join(m.workspace.Directory(), "tasks.csv"), []byte(in), 0600))
+
+	press(m, key(tea.KeyCtrlX), runes("i"), runes("tasks.csv"), key(tea.KeyEnter))
+
+	require.NotNil(t, a.Head())
+	assert.Equal(t, "First", a.Head().Title())
+	assert.Same(t, a.Head(), a.Tail())
+	assert.Contains(t, m.statusLine, "Imported 1 items, skipped 1 malformed rows")
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openDailyNote places the cursor on the item titled with the current
+// date under the journal item set by the config, creating them if
+// absent. The note of the day is looked up among the journal children
+// by the title.
+func (m *Outline) openDailyNote() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+
+	m.workspace.BeginBatch()
+	defer m.workspace.EndBatch()
+
+	journal := m.workspace.Inbox(m.config.Journal)
+	note, _ := journal.FindOrCreateChild(m.now().Format(m.config.JournalFormat))
+
+	return m.reveal(note)
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDailyNote(t *testing.T) {
+	m, a, b, _ := newTestOutline(t)
+	m.now = func() time.Time {
+		return time.Date(2025, 6, 14, 10, 30, 0, 0, time.Local)
+	}
+	m.workspace.SetRoot(b)
+
+	press(m, key(tea.KeyCtrlX), runes("j"))
+	note := m.workspace.Cursor()
+	assert.Equal(t, "2025-06-14", note.Title())
+	assert.Equal(t, "2025-06-14", m.textInput.Value())
+	journal := note.Parent()
+	require.NotNil(t, journal)
+	assert.Equal(t, "Journal", journal.Title())
+	assert.Same(t, m.workspace.Root(), journal.Parent())
+
+	// the existing note is reused
+	m.moveCursor(a)
+	press(m, key(tea.KeyCtrlX), runes("j"))
+	assert.Same(t, note, m.workspace.Cursor())
+	assert.Same(t, note, journal.Head())
+	assert.Same(t, note, journal.Tail())
+
+	m.config.JournalFormat = "Monday, January 2"
+	press(m, key(tea.KeyCtrlX), runes("j"))
+	assert.Equal(t, "Saturday, June 14", m.workspace.Cursor().Title())
+	assert.Same(t, journal, m.workspace.Cursor().Parent())
+
+	// creating the journal and the note is a single step
+	press(m, key(tea.KeyCtrlZ), key(tea.KeyCtrlZ))
+	assert.Nil(t, journal.Parent())
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+// jumpToStatus moves the cursor to the nearest displayed item with the
+// status, below the cursor if forward is true and above it otherwise.
+// If enabled, the search continues from the other end of the list.
+func (m *Outline) jumpToStatus(s data.Status, forward bool) (tea.Model, tea.Cmd) {
+	cur := m.workspace.Cursor()
+
+	next := func(item *data.Item) *data.Item {
+		if forward {
+			return item.NextWithStatus(s)
+		}
+		return item.PrevWithStatus(s)
+	}
+
+	item := next(cur)
+	for item != nil && m.isHidden(item) {
+		item = next(item)
+	}
+
+	if item == nil && m.config.WrapStatusJumps {
+		rows := m.displayedRows()
+		if !forward {
+			slices.Reverse(rows)
+		}
+
+		idx := slices.IndexFunc(rows, func(r *data.Item) bool { return r.Status() == s })
+		if idx >= 0 && rows[idx] != cur {
+			item = rows[idx]
+		}
+	}
+
+	if item == nil {
+		m.statusLine = m.renderStatusError("No more " + s.String() + " items")
+		return m, nil
+	}
+
+	m.statusLine = ""
+	return m.moveCursor(item)
+}
+
+// statusJumpMode picks the status of the item the cursor jumps to.
+type statusJumpMode struct {
+	*Outline
+
+	forward bool
+}
+
+func (m statusJumpMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m.fileChanged(m)
+	case tea.WindowSizeMsg:
+		m.updateWindowSize(msg)
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyEsc {
+			m.Outline.statusLine = ""
+			return m.Outline, nil
+		}
+
+		switch m.keys.statusJump.match(msg) {
+		case "jumpNone":
+			return m.jumpToStatus(data.StatusNone, m.forward)
+		case "jumpToDo":
+			return m.jumpToStatus(data.StatusToDo, m.forward)
+		case "jumpDone":
+			return m.jumpToStatus(data.StatusDone, m.forward)
+		case "jumpCanceled":
+			return m.jumpToStatus(data.StatusCanceled, m.forward)
+		case "jumpWaiting":
+			return m.jumpToStatus(data.StatusWaiting, m.forward)
+		case "jumpScheduled":
+			return m.jumpToStatus(data.StatusScheduled, m.forward)
+		default:
+			return m, nil
+		}
+	}
+
+	return m.Outline, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestJumpToStatus(t *testing.T) {
+	t.Run("PickStatus", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+		b.SetStatus(data.StatusWaiting)
+		c.SetStatus(data.StatusToDo)
+
+		press(m, key(tea.KeyCtrlX), runes("n"), runes("t"))
+		assert.Same(t, c, m.workspace.Cursor())
+
+		press(m, key(tea.KeyCtrlX), runes("N"), runes("w"))
+		assert.Same(t, b, m.workspace.Cursor())
+
+		press(m, key(tea.KeyCtrlX), runes("N"), runes("w"))
+		assert.Same(t, b, m.workspace.Cursor())
+		assert.Contains(t, m.statusLine, "No more WAIT items")
+
+		press(m, key(tea.KeyCtrlX), runes("N"), runes("n"))
+		assert.Same(t, a, m.workspace.Cursor())
+	})
+
+	t.Run("SameStatus", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+		a.SetStatus(data.StatusWaiting)
+		c.SetStatus(data.StatusWaiting)
+		b.Append(m.workspace.NewItem("Nested"))
+		b.Head().SetStatus(data.StatusWaiting)
+		b.SetCollapsed(true, false)
+
+		// the collapsed subtrees are skipped
+		press(m, tea.KeyMsg{Type: tea.KeyDown, Alt: true})
+		assert.Same(t, c, m.workspace.Cursor())
+
+		press(m, tea.KeyMsg{Type: tea.KeyUp, Alt: true})
+		assert.Same(t, a, m.workspace.Cursor())
+	})
+
+	t.Run("HiddenItems", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+		a.SetStatus(data.StatusDone)
+		b.SetStatus(data.StatusCanceled)
+		c.SetStatus(data.StatusCanceled)
+		b.Append(m.workspace.NewItem("Nested"))
+		b.Head().SetStatus(data.StatusDone)
+		m.hideCanceled = true
+
+		press(m, tea.KeyMsg{Type: tea.KeyDown, Alt: true})
+		assert.Same(t, a, m.workspace.Cursor())
+	})
+
+	t.Run("Wraparound", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+		a.SetStatus(data.StatusToDo)
+		b.SetStatus(data.StatusToDo)
+		m.moveCursor(b)
+
+		press(m, tea.KeyMsg{Type: tea.KeyDown, Alt: true})
+		assert.Same(t, b, m.workspace.Cursor())
+
+		m.config.WrapStatusJumps = true
+		press(m, tea.KeyMsg{Type: tea.KeyDown, Alt: true})
+		assert.Same(t, a, m.workspace.Cursor())
+		press(m, tea.KeyMsg{Type: tea.KeyUp, Alt: true})
+		assert.Same(t, b, m.workspace.Cursor())
+
+		// the only item with the status stays
+		m.moveCursor(c)
+		press(m, tea.KeyMsg{Type: tea.KeyDown, Alt: true})
+		assert.Same(t, c, m.workspace.Cursor())
+		assert.Contains(t, m.statusLine, "No more NONE items")
+	})
+}
@@ -0,0 +1,355 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/boogie-byte/oli/internal/config"
+)
+
+// binding maps the keys to a named action.
+type binding struct {
+	name string
+	keys []string
+
+	// description shown in the help view and, for the mode bindings,
+	// in the mode menu, where the key is put in brackets
+	help string
+}
+
+// keySection is the set of bindings active in a mode.
+type keySection struct {
+	title    string
+	bindings []binding
+}
+
+// keyMap holds the bindings of the outline and of every mode, so the
+// key handling, the mode menus and the help view share them.
+type keyMap struct {
+	global        keySection
+	command       keySection
+	item          keySection
+	itemStatus    keySection
+	itemPriority  keySection
+	view          keySection
+	statusFilter  keySection
+	search        keySection
+	statusJump    keySection
+	quitConfirm   keySection
+	fileChanged   keySection
+	reloadConfirm keySection
+	trash         keySection
+}
+
+func defaultKeyMap() *keyMap {
+	return &keyMap{
+		global: keySection{
+			title: "outline",
+			bindings: []binding{
+				{"cursorUp", []string{"ctrl+up"}, "move the cursor up"},
+				{"cursorDown", []string{"ctrl+down"}, "move the cursor down"},
+				{"cursorToParent", []string{"ctrl+left"}, "move the cursor to the parent"},
+				{"cursorToTail", []string{"ctrl+right"}, "move the cursor to the last child"},
+				{"pageUp", []string{"pgup"}, "move the cursor a page up"},
+				{"pageDown", []string{"pgdown"}, "move the cursor a page down"},
+				{"cursorToFirst", []string{"ctrl+home"}, "move the cursor to the first row"},
+				{"cursorToLast", []string{"ctrl+end"}, "move the cursor to the last row"},
+				{"nextSameStatus", []string{"alt+down"}, "move the cursor to the next item with the same status"},
+				{"prevSameStatus", []string{"alt+up"}, "move the cursor to the previous item with the same status"},
+				{"moveUp", []string{"ctrl+shift+up"}, "move the item up"},
+				{"moveDown", []string{"ctrl+shift+down"}, "move the item down"},
+				{"moveToTop", []string{"ctrl+shift+home"}, "move the item to the top of the list"},
+				{"moveToBottom", []string{"ctrl+shift+end"}, "move the item to the bottom of the list"},
+				{"demote", []string{"ctrl+shift+right"}, "demote the item"},
+				{"promote", []string{"ctrl+shift+left"}, "promote the item"},
+				{"addSibling", []string{"tab"}, "add a sibling"},
+				{"addChild", []string{"shift+tab"}, "add a child"},
+				{"splitItem", []string{"alt+enter"}, "split the item at the text cursor"},
+				{"cycleStatus", []string{"f2"}, "cycle the item status"},
+				{"cycleStatusBack", []string{"f14"}, "cycle the item status backward (shift+f2)"},
+				{"repeat", []string{"ctrl+r"}, "repeat the last action"},
+				{"scratch", []string{"ctrl+n"}, "capture into the scratch list"},
+				{"timestamp", []string{"ctrl+t"}, "insert a timestamp"},
+				{"select", []string{"ctrl+@"}, "select the item"},
+				{"undo", []string{"ctrl+z"}, "undo"},
+				{"redo", []string{"ctrl+y"}, "redo"},
+				{"commandMode", []string{"ctrl+x"}, "command menu"},
+				{"itemMode", []string{"ctrl+c"}, "item menu"},
+				{"help", []string{"f1"}, "show this help"},
+				{"cancel", []string{"esc"}, "clear the selection and the status line"},
+			},
+		},
+		command: keySection{
+			title: "command",
+			bindings: []binding{
+				{"quit", []string{"q"}, "[q]uit"},
+				{"save", []string{"s"}, "[s]ave file"},
+				{"readSubtree", []string{"r"}, "[r]ead subtree"},
+				{"reload", []string{"R"}, "[R]eload file"},
+				{"goTo", []string{"g"}, "[g]o to"},
+				{"openDocument", []string{"o"}, "[o]pen document"},
+				{"trash", []string{"T"}, "[T]rash"},
+				{"dueSoon", []string{"d"}, "[d]ue soon"},
+				{"agenda", []string{"a"}, "[a]genda"},
+				{"viewMode", []string{"v"}, "[v]iew options"},
+				{"search", []string{"/"}, "[/] search"},
+				{"goToMatch", []string{"#"}, "[#] go to match"},
+				{"nextWithStatus", []string{"n"}, "[n]ext with status"},
+				{"prevWithStatus", []string{"N"}, "[N] previous with status"},
+				{"dailyNote", []string{"j"}, "today's [j]ournal note"},
+				{"importCSV", []string{"i"}, "[i]mport CSV"},
+				{"exportMarkdown", []string{"m"}, "export [m]arkdown"},
+				{"exportSubtree", []string{"e"}, "[e]xport subtree as"},
+				{"stats", []string{"t"}, "s[t]atistics"},
+				{"commandHelp", []string{"?"}, "[?] help"},
+			},
+		},
+		item: keySection{
+			title: "item",
+			bindings: []binding{
+				{"completeAncestors", []string{"a"}, "complete [a]ncestors"},
+				{"toggleCase", []string{"c"}, "toggle [c]ase"},
+				{"collapseAll", []string{"C"}, "[C]ollapse all"},
+				{"expandToLevel", []string{"1", "2", "3", "4", "5", "6", "7", "8", "9"}, "expand to level [1]-9"},
+				{"deleteItem", []string{"d"}, "[d]elete"},
+				{"deleteRecursive", []string{"D"}, "[D]elete recursive"},
+				{"flatten", []string{"e"}, "flatt[e]n"},
+				{"expandAll", []string{"E"}, "[E]xpand all"},
+				{"fold", []string{"f"}, "[f]old"},
+				{"foldRecursive", []string{"F"}, "[F]old recursive"},
+				{"insertTemplate", []string{"i"}, "[i]nsert template"},
+				{"joinNext", []string{"j"}, "[j]oin next"},
+				{"cut", []string{"k"}, "cut"},
+				{"toggleLeaf", []string{"l"}, "toggle [l]eaf"},
+				{"link", []string{"L"}, "[L]ink to"},
+				{"followLink", []string{"g"}, "[g]o to link"},
+				{"mergeIntoParent", []string{"m"}, "[m]erge into parent"},
+				{"setMark", []string{"M"}, "set [M]ark"},
+				{"jumpToMark", []string{"'"}, "['] jump to mark"},
+				{"editNote", []string{"n"}, "edit [n]ote"},
+				{"priorityMode", []string{"p"}, "set [p]riority"},
+				{"sortChildren", []string{"o"}, "s[o]rt children"},
+				{"sortRecursive", []string{"O"}, "[O] sort recursive"},
+				{"reflow", []string{"r"}, "[r]eflow"},
+				{"statusMode", []string{"s"}, "change [s]tatus"},
+				{"splitList", []string{"S"}, "[S]plit list"},
+				{"tagsToGroups", []string{"t"}, "[t]ags to groups"},
+				{"groupToTag", []string{"T"}, "group to [T]ag"},
+				{"duplicate", []string{"u"}, "d[u]plicate"},
+				{"copy", []string{"w"}, "copy"},
+				{"xml", []string{"x"}, "[x]ml"},
+				{"paste", []string{"y"}, "paste"},
+				{"zoomIn", []string{"z"}, "[z]oom in"},
+				{"zoomOut", []string{"Z"}, "[Z]oom out"},
+				{"zoomHome", []string{"H"}, "zoom [H]ome"},
+				{"archive", []string{"v"}, "archi[v]e completed"},
+				{"archiveRecursive", []string{"V"}, "[V] archive recursive"},
+			},
+		},
+		itemStatus: keySection{
+			title: "item status",
+			bindings: []binding{
+				{"statusNone", []string{"n"}, "[n]one"},
+				{"statusToDo", []string{"t"}, "[t]odo"},
+				{"statusDone", []string{"d"}, "[d]one"},
+				{"statusCanceled", []string{"c"}, "[c]anceled"},
+				{"statusWaiting", []string{"w"}, "[w]aiting"},
+				{"statusScheduled", []string{"s"}, "[s]cheduled"},
+				{"statusRecursive", []string{"r"}, "[r]ecursive"},
+			},
+		},
+		itemPriority: keySection{
+			title: "item priority",
+			bindings: []binding{
+				{"priorityNone", []string{"n"}, "[n]one"},
+				{"priorityA", []string{"a"}, "[a]"},
+				{"priorityB", []string{"b"}, "[b]"},
+				{"priorityC", []string{"c"}, "[c]"},
+			},
+		},
+		view: keySection{
+			title: "view",
+			bindings: []binding{
+				{"toggleChildCount", []string{"c"}, "child [c]ount"},
+				{"toggleAutoExpand", []string{"e"}, "[e]xpand on enter"},
+				{"toggleHideCanceled", []string{"h"}, "[h]ide canceled"},
+				{"toggleSeparators", []string{"s"}, "[s]eparators"},
+				{"toggleWrap", []string{"w"}, "[w]rap titles"},
+				{"filterMode", []string{"f"}, "status [f]ilter"},
+			},
+		},
+		statusFilter: keySection{
+			title: "status filter",
+			bindings: []binding{
+				{"filterNone", []string{"n"}, "[n]one"},
+				{"filterToDo", []string{"t"}, "[t]odo"},
+				{"filterDone", []string{"d"}, "[d]one"},
+				{"filterCanceled", []string{"c"}, "[c]anceled"},
+				{"filterWaiting", []string{"w"}, "[w]aiting"},
+				{"filterScheduled", []string{"s"}, "[s]cheduled"},
+				{"clearFilter", []string{"x"}, "clear [x]"},
+			},
+		},
+		statusJump: keySection{
+			title: "jump to status",
+			bindings: []binding{
+				{"jumpNone", []string{"n"}, "[n]one"},
+				{"jumpToDo", []string{"t"}, "[t]odo"},
+				{"jumpDone", []string{"d"}, "[d]one"},
+				{"jumpCanceled", []string{"c"}, "[c]anceled"},
+				{"jumpWaiting", []string{"w"}, "[w]aiting"},
+				{"jumpScheduled", []string{"s"}, "[s]cheduled"},
+			},
+		},
+		quitConfirm: keySection{
+			title: "unsaved changes",
+			bindings: []binding{
+				{"quitAnyway", []string{"q"}, "[q] quit anyway"},
+				{"saveAndQuit", []string{"s"}, "[s] save and quit"},
+			},
+		},
+		reloadConfirm: keySection{
+			title: "unsaved changes",
+			bindings: []binding{
+				{"reloadAnyway", []string{"R"}, "[R] reload anyway"},
+			},
+		},
+		fileChanged: keySection{
+			title: "file changed on disk",
+			bindings: []binding{
+				{"reloadChanged", []string{"r"}, "[r]eload"},
+				{"ignoreChanged", []string{"i"}, "[i]gnore"},
+			},
+		},
+		trash: keySection{
+			title: "trash",
+			bindings: []binding{
+				{"restoreTrashed", []string{"r", "enter"}, "[r]estore"},
+				{"emptyTrash", []string{"E"}, "[E]mpty trash"},
+			},
+		},
+		search: keySection{
+			title: "search",
+			bindings: []binding{
+				{"nextMatch", []string{"n"}, "[n]ext"},
+				{"prevMatch", []string{"N"}, "[N] previous"},
+				{"toggleScope", []string{"tab"}, "[tab] this subtree or whole document"},
+			},
+		},
+	}
+}
+
+// newKeyMap returns the default bindings with the keys of the actions
+// replaced by the custom ones.
+func newKeyMap(custom map[string]config.KeyList) (*keyMap, error) {
+	k := defaultKeyMap()
+
+	names := slices.Sorted(maps.Keys(custom))
+	for _, name := range names {
+		keys := custom[name]
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("no keys bound to action %q", name)
+		}
+
+		if !k.rebind(name, keys) {
+			return nil, fmt.Errorf("unknown action %q in key bindings", name)
+		}
+	}
+
+	for _, s := range k.sections() {
+		bound := make(map[string]string)
+		for _, b := range s.bindings {
+			for _, key := range b.keys {
+				if other, ok := bound[key]; ok {
+					return nil, fmt.Errorf("key %q is bound to both %q and %q", key, other, b.name)
+				}
+				bound[key] = b.name
+			}
+		}
+	}
+
+	return k, nil
+}
+
+func (k *keyMap) sections() []*keySection {
+	return []*keySection{&k.global, &k.command, &k.item, &k.itemStatus, &k.itemPriority, &k.view, &k.statusFilter, &k.search, &k.statusJump, &k.quitConfirm, &k.reloadConfirm, &k.fileChanged, &k.trash}
+}
+
+// rebind replaces the keys of the action. It reports whether the
+// action exists.
+func (k *keyMap) rebind(name string, keys []string) bool {
+	for _, s := range k.sections() {
+		for i := range s.bindings {
+			if s.bindings[i].name == name {
+				s.bindings[i].keys = keys
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// match returns the name of the action bound to the key, or an empty
+// string if there is none.
+func (s *keySection) match(msg tea.KeyMsg) string {
+	k := msg.String()
+	for _, b := range s.bindings {
+		if slices.Contains(b.keys, k) {
+			return b.name
+		}
+	}
+
+	return ""
+}
+
+// keys returns the keys bound to the action.
+func (s *keySection) keys(name string) []string {
+	for _, b := range s.bindings {
+		if b.name == name {
+			return b.keys
+		}
+	}
+
+	return nil
+}
+
+// menuEntry returns the binding description with the first key in
+// brackets. The key is appended if the description does not mention
+// it.
+func (b binding) menuEntry() string {
+	k := "[" + b.keys[0] + "]"
+	if strings.Contains(b.help, k) {
+		return b.help
+	}
+
+	return b.help + " " + k
+}
+
+// menu returns the status line listing the section bindings.
+func (s *keySection) menu() string {
+	entries := make([]string, len(s.bindings))
+	for i, b := range s.bindings {
+		entries[i] = b.menuEntry()
+	}
+
+	return s.title + ": " + strings.Join(entries, "  ")
+}
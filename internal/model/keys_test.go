@@ -0,0 +1,75 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/config"
+)
+
+func TestCustomKeys(t *testing.T) {
+	t.Run("RebindCursorMovement", func(t *testing.T) {
+		m, a, b, _ := newTestOutline(t)
+
+		cfg := config.Default()
+		cfg.Keys = map[string]config.KeyList{
+			"cursorUp":   {"alt+k"},
+			"cursorDown": {"alt+j"},
+		}
+
+		m, err := NewOutline(m.workspace, cfg)
+		require.NoError(t, err)
+		m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+		altJ := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j"), Alt: true}
+		altK := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k"), Alt: true}
+
+		press(m, altJ)
+		assert.Same(t, b, m.workspace.Cursor())
+
+		press(m, key(tea.KeyCtrlDown))
+		assert.Same(t, b, m.workspace.Cursor())
+
+		press(m, altK)
+		assert.Same(t, a, m.workspace.Cursor())
+	})
+
+	t.Run("ModeKey", func(t *testing.T) {
+		k, err := newKeyMap(map[string]config.KeyList{"deleteItem": {"backspace", "d"}})
+		require.NoError(t, err)
+
+		assert.Contains(t, k.item.menu(), "[d]elete [backspace]")
+	})
+
+	t.Run("UnknownAction", func(t *testing.T) {
+		_, err := newKeyMap(map[string]config.KeyList{"flyAway": {"f"}})
+		assert.ErrorContains(t, err, `unknown action "flyAway"`)
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		_, err := newKeyMap(map[string]config.KeyList{"cursorUp": {"ctrl+down"}})
+		assert.ErrorContains(t, err, `"ctrl+down" is bound to both "cursorUp" and "cursorDown"`)
+	})
+
+	t.Run("NoKeys", func(t *testing.T) {
+		_, err := newKeyMap(map[string]config.KeyList{"undo": {}})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+const linkIndicator = "↪" // U+21AA
+
+// openLinkPalette picks the item the cursor item links to.
+func (m *Outline) openLinkPalette() (tea.Model, tea.Cmd) {
+	source := m.workspace.Cursor()
+
+	return m.openPaletteWith("link to: ", func(m *Outline, target *data.Item) (tea.Model, tea.Cmd) {
+		if target == source {
+			m.statusLine = m.renderStatusError("Item can not link to itself")
+			return m, nil
+		}
+
+		source.AddRef(target.ID())
+		m.statusLine = m.renderStatusMessage("Linked to " + target.Title())
+		return m, nil
+	})
+}
+
+// followLink reveals the first linked item which still exists.
+func (m *Outline) followLink() (tea.Model, tea.Cmd) {
+	item := m.workspace.Cursor()
+
+	targets := item.RefTargets()
+	if len(targets) == 0 {
+		if len(item.Refs()) > 0 {
+			m.statusLine = m.renderStatusError("Linked items no longer exist")
+		} else {
+			m.statusLine = m.renderStatusError("Item has no links")
+		}
+		return m, nil
+	}
+
+	return m.reveal(targets[0])
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinks(t *testing.T) {
+	t.Run("LinkAndFollow", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+		b.Append(c)
+		b.SetCollapsed(true, false)
+
+		model := press(m, key(tea.KeyCtrlC), runes("L"))
+		assert.Contains(t, model.View(), "link to: ")
+		model = press(model, runes("childc"), key(tea.KeyEnter))
+		assert.Same(t, m, model)
+		require.Len(t, a.Refs(), 1)
+		assert.Equal(t, c.ID(), a.Refs()[0])
+		assert.Contains(t, m.renderItemEntry(a), linkIndicator)
+		assert.NotContains(t, m.renderItemEntry(b), linkIndicator)
+
+		press(m, key(tea.KeyCtrlC), runes("g"))
+		assert.Same(t, c, m.workspace.Cursor())
+		assert.False(t, b.Collapsed())
+	})
+
+	t.Run("Self", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+
+		press(m, key(tea.KeyCtrlC), runes("L"), runes("childa"), key(tea.KeyEnter))
+		assert.Empty(t, a.Refs())
+		assert.Contains(t, m.statusLine, "Item can not link to itself")
+	})
+
+	t.Run("Dangling", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+		a.AddRef(b.ID())
+		a.AddRef(c.ID())
+
+		// the deleted targets are skipped
+		b.Detach()
+		press(m, key(tea.KeyCtrlC), runes("g"))
+		assert.Same(t, c, m.workspace.Cursor())
+
+		c.Detach()
+		m.moveCursor(a)
+		press(m, key(tea.KeyCtrlC), runes("g"))
+		assert.Same(t, a, m.workspace.Cursor())
+		assert.Contains(t, m.statusLine, "Linked items no longer exist")
+	})
+
+	t.Run("NoLinks", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+
+		press(m, key(tea.KeyCtrlC), runes("g"))
+		assert.Same(t, a, m.workspace.Cursor())
+		assert.Contains(t, m.statusLine, "Item has no links")
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// markMode reads the letter of the mark to set on the cursor item or
+// to jump to.
+type markMode struct {
+	*Outline
+
+	set bool
+}
+
+func (m *Outline) readMark(set bool) (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+
+	if set {
+		m.statusLine = "set mark: press a letter"
+	} else {
+		m.statusLine = "jump to mark: press a letter"
+	}
+
+	return markMode{Outline: m, set: set}, nil
+}
+
+// jumpToMark reveals the item marked with the letter.
+func (m *Outline) jumpToMark(r rune) (tea.Model, tea.Cmd) {
+	item, ok := m.workspace.Mark(r)
+	if !ok {
+		m.statusLine = m.renderStatusError(fmt.Sprintf("Mark %c not found", r))
+		return m, nil
+	}
+
+	m.statusLine = ""
+	return m.reveal(item)
+}
+
+func (m markMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m.fileChanged(m)
+	case tea.WindowSizeMsg:
+		m.updateWindowSize(msg)
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyEsc {
+			m.Outline.statusLine = ""
+			return m.Outline, nil
+		}
+
+		if msg.Type != tea.KeyRunes || len(msg.Runes) != 1 || !unicode.IsLetter(msg.Runes[0]) {
+			return m, nil
+		}
+
+		r := msg.Runes[0]
+		if !m.set {
+			return m.jumpToMark(r)
+		}
+
+		m.workspace.SetMark(r, m.workspace.Cursor())
+		m.Outline.statusLine = m.renderStatusMessage(fmt.Sprintf("Mark %c set", r))
+		return m.Outline, nil
+	}
+
+	return m, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarks(t *testing.T) {
+	t.Run("SetAndJump", func(t *testing.T) {
+		m, a, b, _ := newTestOutline(t)
+		nested := m.workspace.NewItem("Nested")
+		a.Append(nested)
+		m.moveCursor(nested)
+
+		model := press(m, key(tea.KeyCtrlC), runes("M"))
+		require.IsType(t, markMode{}, model)
+		assert.Equal(t, "set mark: press a letter", m.statusLine)
+
+		press(model, runes("q"))
+		assert.Contains(t, m.statusLine, "Mark q set")
+
+		a.SetCollapsed(true, false)
+		m.workspace.SetRoot(b)
+		m.moveCursor(b)
+
+		model = press(m, key(tea.KeyCtrlC), runes("'"), runes("q"))
+		assert.Same(t, m, model)
+		assert.Same(t, nested, m.workspace.Cursor())
+		assert.Same(t, m.workspace.Root().RealRoot(), m.workspace.Root())
+		assert.False(t, a.Collapsed())
+		assert.Empty(t, m.statusLine)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		m, a, b, _ := newTestOutline(t)
+		m.workspace.SetMark('q', b)
+		b.Trash()
+
+		press(m, key(tea.KeyCtrlC), runes("'"), runes("q"))
+		assert.Same(t, a, m.workspace.Cursor())
+		assert.Contains(t, m.statusLine, "Mark q not found")
+
+		// the stale mark is cleared
+		b.Restore()
+		press(m, key(tea.KeyCtrlC), runes("'"), runes("q"))
+		assert.Same(t, a, m.workspace.Cursor())
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		m, _, _, _ := newTestOutline(t)
+
+		model := press(m, key(tea.KeyCtrlC), runes("'"), runes("1"))
+		require.IsType(t, markMode{}, model)
+
+		model = press(model, key(tea.KeyEsc))
+		assert.Same(t, m, model)
+		assert.Empty(t, m.statusLine)
+	})
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+// listTop is the screen line of the first item row, below the
+// breadcrumbs.
+const listTop = 3
+
+// lineWidth returns the rendered width of the item list line.
+func (m *Outline) lineWidth(item *data.Item) int {
+	if item == nil {
+		return m.windowWidth
+	}
+
+	return m.windowWidth - getLinePadding(item)
+}
+
+// clickedItem returns the item rendered at the screen position and
+// whether the position is over its bullet. It returns nil for the
+// positions outside of the item rows.
+func (m *Outline) clickedItem(x, y int) (*data.Item, bool) {
+	lines := m.visibleLines(m.scrollToCursor())
+
+	// a wrapped title takes several screen lines, only the first one
+	// has the bullet
+	idx, row := -1, y-listTop
+	for i, item := range lines {
+		if row < 0 {
+			break
+		}
+		if row < m.lineHeight(item) {
+			idx = i
+			break
+		}
+		row -= m.lineHeight(item)
+	}
+	if idx < 0 || lines[idx] == nil {
+		return nil, false
+	}
+
+	// the rows are right-aligned to the widest one, so the indentation
+	// is on the left
+	width := 0
+	for _, item := range lines {
+		width = max(width, m.lineWidth(item))
+	}
+
+	item := lines[idx]
+	start := width - m.lineWidth(item)
+	if x < start {
+		return nil, false
+	}
+
+	return item, row == 0 && x < start+prefixWitdh
+}
+
+func (m *Outline) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
+		return m, nil
+	}
+
+	item, onBullet := m.clickedItem(msg.X, msg.Y)
+	if item == nil {
+		return m, nil
+	}
+
+	if !onBullet || item.Head() == nil {
+		return m.moveCursor(item)
+	}
+
+	item.SetCollapsed(!item.Collapsed(), false)
+
+	// keep the cursor out of the folded subtree
+	for p := m.workspace.Cursor().Parent(); p != nil; p = p.Parent() {
+		if p == item && item.Collapsed() {
+			return m.moveCursor(item)
+		}
+	}
+
+	return m, nil
+}
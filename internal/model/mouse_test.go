@@ -0,0 +1,97 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-runewidth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func click(x, y int) tea.MouseMsg {
+	return tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft}
+}
+
+// screenPos returns the position of the text in the rendered view.
+func screenPos(t *testing.T, m tea.Model, text string) (int, int) {
+	t.Helper()
+
+	for y, line := range strings.Split(ansi.Strip(m.View()), "\n") {
+		if idx := strings.Index(line, text); idx >= 0 {
+			return runewidth.StringWidth(line[:idx]), y
+		}
+	}
+
+	require.Failf(t, "text not found", "%q", text)
+	return 0, 0
+}
+
+func TestMouse(t *testing.T) {
+	t.Run("ClickRow", func(t *testing.T) {
+		m, _, b, c := newTestOutline(t)
+
+		x, y := screenPos(t, m, "ChildB")
+		m.Update(click(x+2, y))
+		assert.Same(t, b, m.workspace.Cursor())
+
+		_, y = screenPos(t, m, "ChildC")
+		m.Update(click(0, y))
+		assert.Same(t, c, m.workspace.Cursor())
+	})
+
+	t.Run("ClickBullet", func(t *testing.T) {
+		m, a, b, _ := newTestOutline(t)
+		child := m.workspace.NewItem("Nested")
+		a.Append(child)
+		m.moveCursor(child)
+
+		// the indentation of the nested rows is not a part of them
+		_, y := screenPos(t, m, "Nested")
+		m.Update(click(0, y))
+		assert.Same(t, child, m.workspace.Cursor())
+
+		x, y := screenPos(t, m, bulletTriangleDown)
+		m.Update(click(x, y))
+		assert.True(t, a.Collapsed())
+		assert.Same(t, a, m.workspace.Cursor())
+
+		x, y = screenPos(t, m, bulledTriangleRight)
+		m.Update(click(x+1, y))
+		assert.False(t, a.Collapsed())
+
+		// a leaf bullet only moves the cursor
+		x, y = screenPos(t, m, "ChildB")
+		m.Update(click(x-2, y))
+		assert.Same(t, b, m.workspace.Cursor())
+	})
+
+	t.Run("OutsideOfList", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+
+		m.Update(click(5, 0))
+		m.Update(click(5, listTop+10))
+		m.Update(click(5, 23))
+		assert.Same(t, a, m.workspace.Cursor())
+
+		_, y := screenPos(t, m, "ChildB")
+		m.Update(tea.MouseMsg{X: 5, Y: y, Action: tea.MouseActionRelease, Button: tea.MouseButtonLeft})
+		assert.Same(t, a, m.workspace.Cursor())
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+const noteIndicator = "≡" // U+2261
+
+// noteMode edits the note of an item in a full-screen text area. Esc
+// stores the note and returns to the outline.
+type noteMode struct {
+	*Outline
+
+	item   *data.Item
+	editor textarea.Model
+}
+
+func (m *Outline) editNote() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+	m.statusLine = ""
+
+	n := noteMode{Outline: m, item: m.workspace.Cursor()}
+	n.editor = textarea.New()
+	n.editor.ShowLineNumbers = false
+	n.editor.Prompt = ""
+	n.editor.MaxHeight = 0
+	n.editor.SetValue(n.item.Note())
+	n.resize()
+
+	return n, n.editor.Focus()
+}
+
+// resize fits the text area between the header and the hint lines.
+func (m *noteMode) resize() {
+	m.editor.SetWidth(m.windowWidth)
+	m.editor.SetHeight(max(m.windowHeight-2, 1))
+}
+
+func (m noteMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m.fileChanged(m)
+	case tea.WindowSizeMsg:
+		m.updateWindowSize(msg)
+		m.resize()
+		return m, nil
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyEsc {
+			m.item.SetNote(m.editor.Value())
+			return m.finishEdit(m.Outline, nil)
+		}
+	}
+
+	var cmd tea.Cmd
+	m.editor, cmd = m.editor.Update(message)
+	return m, cmd
+}
+
+func (m noteMode) View() string {
+	if m.windowWidth == 0 || m.windowHeight == 0 {
+		return ""
+	}
+
+	header := runewidth.Truncate("note: "+m.item.Title(), m.windowWidth, "...")
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		m.styles.breadcrumbs.Render(header),
+		m.editor.View(),
+		m.styles.statusLineHint.Render("Esc to save and return"),
+	)
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditNote(t *testing.T) {
+	m, a, b, _ := newTestOutline(t)
+	a.SetNote("Existing")
+
+	model := press(m, key(tea.KeyCtrlC), runes("n"))
+	require.IsType(t, noteMode{}, model)
+	assert.Contains(t, model.View(), "Existing")
+
+	model = press(model, key(tea.KeyEnter), runes("More"), key(tea.KeyEsc))
+	assert.Same(t, m, model)
+	assert.Equal(t, "Existing\nMore", a.Note())
+
+	assert.Contains(t, m.renderItemEntry(a), noteIndicator)
+	assert.NotContains(t, m.renderItemEntry(b), noteIndicator)
+
+	press(m, key(tea.KeyCtrlZ))
+	assert.Equal(t, "Existing", a.Note())
+}
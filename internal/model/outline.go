@@ -15,13 +15,19 @@
 package model
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/fsnotify/fsnotify"
 	"github.com/mattn/go-runewidth"
 
+	"github.com/boogie-byte/oli/internal/config"
 	"github.com/boogie-byte/oli/internal/data"
 )
 
@@ -31,27 +37,116 @@ const (
 	bulledTriangleRight = "▶" // U+25B6
 	bulletTriangleDown  = "▼" // U+25BC
 
+	separatorChar = "─" // U+2500
+
+	unsavedIndicator = "●" // U+25CF
+
 	prefixWitdh = 3
 )
 
 type Outline struct {
 	workspace *data.Workspace
+	config    *config.Config
+	styles    *styles
+
+	// clock, replaced in tests
+	now func() time.Time
 
 	windowWidth  int
 	windowHeight int
 
+	// index of the topmost visible row of the item list
+	offset int
+
+	// center the cursor line once the window size is known, since the
+	// stored topmost item is missing
+	centerPending bool
+
 	textInput textinput.Model
 
-	commandMode    commandMode
-	itemMode       itemMode
-	itemStatusMode itemStatusMode
+	commandMode      commandMode
+	itemMode         itemMode
+	itemStatusMode   itemStatusMode
+	itemPriorityMode itemPriorityMode
+	viewMode         viewMode
+	statusFilterMode statusFilterMode
+
+	// key bindings of the outline and its modes
+	keys *keyMap
+
+	// last mutating action, replayed by the repeat command
+	lastAction action
+
+	// view state saved by the quick capture
+	capture *capture
+
+	// view toggles
+	showChildCount bool
+	autoExpand     bool
+	hideCanceled   bool
+	showSeparators bool
+	wrapTitles     bool
+
+	// statuses of the displayed items, all of them if empty
+	statusFilter map[data.Status]bool
+
+	// last search results
+	search search
+
+	// whether the search is limited to the subtree of the view root
+	searchSubtree bool
+
+	// items picked for batch operations
+	selection selection
+
+	// buffer of the displayed rows, reused by every render
+	rows []*data.Item
+
+	// watcher of the workspace files and its events, nil if they are
+	// not watched
+	watcher    *fsnotify.Watcher
+	fileEvents chan struct{}
+
+	// set when the workspace file changed during an edit, to ask about
+	// it once the edit is done
+	fileChangePending bool
+
+	// detached subtree which was cut or copied last
+	clipboard *data.Item
+
+	// fold states of the subtrees zoomed out of
+	folds *foldMemory
+
+	// time of the last key press in the outline, and whether the status
+	// line shows the autosave message
+	lastKeyAt time.Time
+	autosaved bool
 
 	statusLine string
 }
 
-func NewOutline(workspace *data.Workspace) (*Outline, error) {
+func NewOutline(workspace *data.Workspace, cfg *config.Config) (*Outline, error) {
+	keys, err := newKeyMap(cfg.Keys)
+	if err != nil {
+		return nil, err
+	}
+
+	styles, err := newThemeStyles(cfg.Theme)
+	if err != nil {
+		return nil, err
+	}
+
 	m := &Outline{
 		workspace: workspace,
+		config:    cfg,
+		styles:    styles,
+		now:       time.Now,
+
+		keys:       keys,
+		autoExpand: cfg.AutoExpand,
+		wrapTitles: cfg.WrapTitles,
+		selection:  make(selection),
+		folds:      newFoldMemory(foldMemorySize),
 	}
 
 	m.textInput = textinput.New()
@@ -61,7 +156,16 @@ func NewOutline(workspace *data.Workspace) (*Outline, error) {
 
 	m.commandMode = commandMode{m}
 	m.itemMode = itemMode{m}
-	m.itemStatusMode = itemStatusMode{m}
+	m.itemStatusMode = itemStatusMode{Outline: m}
+	m.itemPriorityMode = itemPriorityMode{m}
+	m.viewMode = viewMode{m}
+	m.statusFilterMode = statusFilterMode{m}
+
+	m.restoreScroll()
+
+	if name := workspace.RestoredFrom(); name != "" {
+		m.statusLine = m.renderStatusError(fmt.Sprintf("Workspace file is corrupt, restored the backup %s", name))
+	}
 
 	return m, nil
 }
@@ -81,34 +185,125 @@ func getBullet(item *data.Item) string {
 	}
 }
 
-func getStatus(item *data.Item) string {
+func (m *Outline) getStatus(item *data.Item) string {
 	if s := item.Status(); s != data.StatusNone {
-		return styleItemStatus[s].Render(s.String())
+		return m.styles.itemStatus[s].Render(s.String())
+	}
+
+	return ""
+}
+
+func (m *Outline) getPriority(item *data.Item) string {
+	if p := item.Priority(); p != data.PriorityNone {
+		return m.styles.priority[p].Render("[#" + p.String() + "]")
 	}
 
 	return ""
 }
 
-func getItemStyle(item *data.Item) lipgloss.Style {
+func (m *Outline) getItemStyle(item *data.Item) lipgloss.Style {
 	switch item.Status() {
 	case data.StatusDone, data.StatusCanceled:
-		return styleItemComplete
+		return m.styles.getItemCompleteStyle()
 	default:
-		return styleItemNormal
+		return m.styles.itemNormal
 	}
 }
 
-func (m *Outline) getMaxTitleWidth(padding int) int {
-	return m.windowWidth - padding - prefixWitdh
+// getItemMeta returns the rendered item metadata shown after the title.
+func (m *Outline) getItemMeta(item *data.Item) string {
+	var note string
+	if item.Note() != "" {
+		note = m.styles.noteIndicator.Render(noteIndicator)
+	}
+
+	var link string
+	if len(item.Refs()) > 0 {
+		link = m.styles.linkIndicator.Render(linkIndicator)
+	}
+
+	var due string
+	if d := item.Due(); !d.IsZero() {
+		due = m.styles.due.Render(d.Format(data.DueLayout))
+	}
+
+	var completed string
+	if c := item.Completed(); m.config.ShowCompleted && item.Status() == data.StatusDone && !c.IsZero() {
+		completed = m.styles.completed.Render("done " + c.Format(data.DueLayout))
+	}
+
+	var todoStats string
+	if done, total := m.toDoStats(item); done != 0 || total != 0 {
+		todoStats = fmt.Sprintf("(%d/%d)", done, total)
+		if m.config.ProgressBars {
+			todoStats += " " + renderProgressBar(done, total)
+		}
+		todoStats = m.styles.todoStats.Render(todoStats)
+	}
+
+	var tags string
+	if t := item.Tags(); len(t) > 0 {
+		tags = m.styles.tags.Render(strings.Join(t, " "))
+	}
+
+	return note + link + due + completed + todoStats + m.getChildCountBadge(item) + tags
 }
 
-func (m *Outline) breadcrumbs() string {
-	var breadcrumbs string
-	for p := m.workspace.Root().Parent(); p != nil; p = p.Parent() {
-		breadcrumbs = p.Title() + " / " + breadcrumbs
+// toDoStats returns the to-do statistics of the children of the item
+// or, if enabled, of all its descendants.
+func (m *Outline) toDoStats(item *data.Item) (int, int) {
+	if m.config.DeepToDoStats {
+		return item.ToDoStatsDeep()
 	}
 
-	return breadcrumbs
+	return item.ToDoStats()
+}
+
+// getChildCountBadge returns the rendered number of the item children
+// if the badge is enabled and the item has any.
+func (m *Outline) getChildCountBadge(item *data.Item) string {
+	if !m.showChildCount {
+		return ""
+	}
+
+	if n := item.ChildCount(); n > 0 {
+		return m.styles.childCount.Render(fmt.Sprintf("[%d]", n))
+	}
+
+	return ""
+}
+
+func (m *Outline) getMaxTitleWidth(item *data.Item) int {
+	width := m.windowWidth - getLinePadding(item) - prefixWitdh
+	width -= lipgloss.Width(m.getStatus(item))
+	width -= lipgloss.Width(m.getPriority(item))
+	width -= lipgloss.Width(m.getItemMeta(item))
+
+	return width
+}
+
+// titleLines returns the title of the item not under the cursor fitted
+// to the row, either wrapped or truncated.
+func (m *Outline) titleLines(item *data.Item) []string {
+	width := m.getMaxTitleWidth(item)
+	if !m.wrapTitles || width < 1 {
+		return []string{runewidth.Truncate(item.Title(), width, "...")}
+	}
+
+	// lipgloss wraps by the display width, so the double-width runes
+	// are accounted for
+	wrapped := lipgloss.NewStyle().Width(width).Render(item.Title())
+
+	lines := strings.Split(wrapped, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " ")
+	}
+
+	return lines
+}
+
+func (m *Outline) breadcrumbs() string {
+	return itemPath(m.workspace.Root())
 }
 
 // Movement
@@ -117,9 +312,14 @@ func (m *Outline) saveCurrentTitle() {
 	m.workspace.Cursor().SetTitle(m.textInput.Value())
 }
 
+// unsaved reports whether there are changes not written to the
+// workspace file, the title being edited included.
+func (m *Outline) unsaved() bool {
+	return m.workspace.Dirty() || m.textInput.Value() != m.workspace.Cursor().Title()
+}
+
 func (m *Outline) updateTextInput(n *data.Item) {
-	padding := getLinePadding(n)
-	maxWidth := m.getMaxTitleWidth(padding)
+	maxWidth := m.getMaxTitleWidth(n)
 
 	m.textInput.Width = 0
 	if runewidth.StringWidth(n.Title()) > maxWidth {
@@ -137,18 +337,70 @@ func (m *Outline) moveCursor(item *data.Item) (tea.Model, tea.Cmd) {
 	m.updateTextInput(item)
 	m.textInput.CursorEnd()
 
+	if m.autoExpand && item != m.workspace.Cursor() {
+		item.SetCollapsed(false, false)
+	}
+
 	m.workspace.SetCursor(item)
+	m.scrollToCursor()
 
 	return m, nil
 }
 
+// prevDisplayedRow returns the row above the item, skipping the hidden
+// ones, or nil if there is none.
+func (m *Outline) prevDisplayedRow(item *data.Item) *data.Item {
+	item = item.PrevRow()
+	for item != nil && m.isHidden(item) {
+		item = item.PrevRow()
+	}
+	return item
+}
+
+// nextDisplayedRow returns the row below the item, skipping the hidden
+// ones, or nil if there is none.
+func (m *Outline) nextDisplayedRow(item *data.Item) *data.Item {
+	item = item.NextRow()
+	for item != nil && m.isHidden(item) {
+		item = item.NextRow()
+	}
+	return item
+}
+
 func (m *Outline) cursorUp() (tea.Model, tea.Cmd) {
-	item := m.workspace.Cursor().PrevRow()
-	return m.moveCursor(item)
+	return m.moveCursor(m.prevDisplayedRow(m.workspace.Cursor()))
 }
 
 func (m *Outline) cursorDown() (tea.Model, tea.Cmd) {
-	item := m.workspace.Cursor().NextRow()
+	return m.moveCursor(m.nextDisplayedRow(m.workspace.Cursor()))
+}
+
+// cursorPage moves the cursor a page of rows up or down, stopping at the
+// first or the last row, and scrolls the list along.
+func (m *Outline) cursorPage(forward bool) (tea.Model, tea.Cmd) {
+	// the page size is unknown until the window size is set
+	if m.windowHeight == 0 {
+		return m, nil
+	}
+
+	step := m.prevDisplayedRow
+	page := -m.listHeight()
+	if forward {
+		step = m.nextDisplayedRow
+		page = -page
+	}
+
+	item := m.workspace.Cursor()
+	for range m.listHeight() {
+		next := step(item)
+		if next == nil {
+			break
+		}
+		item = next
+	}
+
+	// moving the cursor clamps the offset and keeps the cursor visible
+	m.offset = max(m.offset+page, 0)
 	return m.moveCursor(item)
 }
 
@@ -173,6 +425,24 @@ func (m *Outline) cursorToTail() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func (m *Outline) cursorToFirst() (tea.Model, tea.Cmd) {
+	rows := m.displayedRows()
+	if len(rows) == 0 {
+		return m, nil
+	}
+
+	return m.moveCursor(rows[0])
+}
+
+func (m *Outline) cursorToLast() (tea.Model, tea.Cmd) {
+	rows := m.displayedRows()
+	if len(rows) == 0 {
+		return m, nil
+	}
+
+	return m.moveCursor(rows[len(rows)-1])
+}
+
 func (m *Outline) zoomIn() (tea.Model, tea.Cmd) {
 	cur := m.workspace.Cursor()
 	if cur.Head() == nil {
@@ -180,6 +450,7 @@ func (m *Outline) zoomIn() (tea.Model, tea.Cmd) {
 	}
 
 	m.workspace.SetRoot(cur)
+	m.folds.restore(cur)
 	m.moveCursor(cur.Head())
 
 	return m, nil
@@ -191,6 +462,7 @@ func (m *Outline) zoomOut() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	m.folds.save(root)
 	m.workspace.SetRoot(root.Parent())
 
 	if root.Collapsed() {
@@ -200,6 +472,20 @@ func (m *Outline) zoomOut() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// zoomToRealRoot zooms out to the real root at once, placing the cursor
+// on the former view root.
+func (m *Outline) zoomToRealRoot() (tea.Model, tea.Cmd) {
+	root := m.workspace.Root()
+	if root.Parent() == nil {
+		return m, nil
+	}
+
+	m.folds.save(root)
+	m.workspace.SetRoot(root.RealRoot())
+
+	return m.reveal(root)
+}
+
 // Row organizing
 
 func (m *Outline) moveRowUp() (tea.Model, tea.Cmd) {
@@ -214,6 +500,18 @@ func (m *Outline) moveRowDown() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func (m *Outline) moveRowToTop() (tea.Model, tea.Cmd) {
+	m.workspace.Cursor().MoveToTop()
+
+	return m, nil
+}
+
+func (m *Outline) moveRowToBottom() (tea.Model, tea.Cmd) {
+	m.workspace.Cursor().MoveToBottom()
+
+	return m, nil
+}
+
 func (m *Outline) toggleItemFolded(recursive bool) (tea.Model, tea.Cmd) {
 	collapsed := m.workspace.Cursor().Collapsed()
 	m.workspace.Cursor().SetCollapsed(!collapsed, recursive)
@@ -221,6 +519,41 @@ func (m *Outline) toggleItemFolded(recursive bool) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// setAllCollapsed collapses or expands every item under the view root.
+// The collapsed view shows the top-level items only, so the cursor moves
+// to its top-level ancestor.
+func (m *Outline) setAllCollapsed(collapsed bool) (tea.Model, tea.Cmd) {
+	root := m.workspace.Root()
+	for c := root.Head(); c != nil; c = c.Next() {
+		c.SetCollapsed(collapsed, true)
+	}
+
+	if !collapsed {
+		return m, nil
+	}
+
+	item := m.workspace.Cursor()
+	for item.Parent() != nil && item.Parent() != root {
+		item = item.Parent()
+	}
+
+	return m.moveCursor(item)
+}
+
+// expandToLevel displays n levels of the items under the view root. If
+// the cursor is deeper, it moves to its ancestor on the last level.
+func (m *Outline) expandToLevel(n int) (tea.Model, tea.Cmd) {
+	root := m.workspace.Root()
+	root.ExpandToLevel(n)
+
+	item := m.workspace.Cursor()
+	for item.Depth() > n && item.Parent() != nil && item.Parent() != root {
+		item = item.Parent()
+	}
+
+	return m.moveCursor(item)
+}
+
 func (m *Outline) toggleBranchCollapsed() (tea.Model, tea.Cmd) {
 	return m, nil
 }
@@ -236,6 +569,51 @@ func (m *Outline) toggleRowDone() (tea.Model, tea.Cmd) {
 	return m.moveCursor(cur.Next())
 }
 
+// setStatus sets the status of the selected items, or of the cursor
+// item if none are selected.
+func (m *Outline) setStatus(s data.Status) (tea.Model, tea.Cmd) {
+	for _, item := range m.targets(false) {
+		item.SetStatus(s)
+	}
+
+	// a canceled item might be hidden now
+	return m.revealCursor()
+}
+
+// setStatusRecursive sets the status of the selected items, or of the
+// cursor item if none are selected, and of all their descendants.
+func (m *Outline) setStatusRecursive(s data.Status) (tea.Model, tea.Cmd) {
+	for _, item := range m.targets(true) {
+		item.SetStatusRecursive(s)
+	}
+	return m.revealCursor()
+}
+
+// cycleStatus sets the cursor item status to the next one in the
+// status cycle, or to the previous one if forward is false.
+func (m *Outline) cycleStatus(forward bool) (tea.Model, tea.Cmd) {
+	s := m.workspace.Cursor().Status()
+	if forward {
+		return m.setStatus(s.Next())
+	}
+
+	return m.setStatus(s.Prev())
+}
+
+// setPriority sets the priority of the cursor item.
+func (m *Outline) setPriority(p data.Priority) (tea.Model, tea.Cmd) {
+	m.workspace.Cursor().SetPriority(p)
+	return m, nil
+}
+
+// completeWithAncestors marks the cursor item and its ancestors under
+// the view root as done.
+func (m *Outline) completeWithAncestors() (tea.Model, tea.Cmd) {
+	m.workspace.Cursor().CompleteWithAncestors(m.workspace.Root(), m.config.CompleteUnstatused)
+
+	return m, nil
+}
+
 func (m *Outline) demoteRow() (tea.Model, tea.Cmd) {
 	m.saveCurrentTitle()
 
@@ -264,7 +642,26 @@ func (m *Outline) updateRow(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// insertTimestamp inserts the current time into the title at the text
+// input cursor.
+func (m *Outline) insertTimestamp() (tea.Model, tea.Cmd) {
+	stamp := []rune(m.now().Format(m.config.TimestampFormat))
+	value := []rune(m.textInput.Value())
+	pos := m.textInput.Position()
+
+	value = append(value[:pos], append(stamp, value[pos:]...)...)
+
+	m.textInput.SetValue(string(value))
+	m.textInput.SetCursor(pos + len(stamp))
+
+	return m, nil
+}
+
 func (m *Outline) deleteItem(recursive bool) (tea.Model, tea.Cmd) {
+	if len(m.selection) > 0 {
+		return m.deleteSelected(recursive)
+	}
+
 	cur := m.workspace.Cursor()
 
 	nextSelected := cur.Next()
@@ -281,11 +678,11 @@ func (m *Outline) deleteItem(recursive bool) (tea.Model, tea.Cmd) {
 	}
 
 	if cur.Head() != nil && !recursive {
-		m.statusLine = styleStatusLineError.Render("Item has children, use C-c D for recursive deletion")
+		m.statusLine = m.renderStatusError("Item has children, use C-c D for recursive deletion")
 		return m, nil
 	}
 
-	cur.Detach()
+	cur.Trash()
 
 	return m.moveCursor(nextSelected)
 }
@@ -303,6 +700,29 @@ func (m *Outline) addSibling() (tea.Model, tea.Cmd) {
 	return m.moveCursor(next)
 }
 
+// splitItem splits the title at the text input cursor, keeping the left
+// part in the cursor item and moving the right part to a new sibling
+// below it, which gets the cursor.
+func (m *Outline) splitItem() (tea.Model, tea.Cmd) {
+	cur := m.workspace.Cursor()
+
+	// the input position counts runes, not bytes
+	value := []rune(m.textInput.Value())
+	pos := m.textInput.Position()
+	left, right := string(value[:pos]), string(value[pos:])
+
+	cur.SetTitle(left)
+	m.textInput.SetValue(left)
+
+	next := m.workspace.NewItem(right)
+	next.MoveBelow(cur)
+
+	m.moveCursor(next)
+	m.textInput.CursorStart()
+
+	return m, nil
+}
+
 func (m *Outline) addChild() (tea.Model, tea.Cmd) {
 	cur := m.workspace.Cursor()
 	next := m.workspace.NewItem("")
@@ -318,14 +738,220 @@ func (m *Outline) addChild() (tea.Model, tea.Cmd) {
 	return m.moveCursor(next)
 }
 
+// toggleLeaf turns a leaf item into a container by adding an empty
+// child to edit, and a container with a single empty child back into
+// a leaf by removing the child.
+func (m *Outline) toggleLeaf() (tea.Model, tea.Cmd) {
+	cur := m.workspace.Cursor()
+
+	head := cur.Head()
+	if head == nil {
+		return m.addChild()
+	}
+
+	if head != cur.Tail() || head.Title() != "" || head.Head() != nil {
+		m.statusLine = m.renderStatusError("Item has children other than a single empty one")
+		return m, nil
+	}
+
+	head.Detach()
+
+	return m, nil
+}
+
+func (m *Outline) reflowItem() (tea.Model, tea.Cmd) {
+	cur := m.workspace.Cursor()
+
+	// The text input collapses newlines, so the paragraphs can only be
+	// found in the stored title.
+	if cur.Reflow() == nil {
+		m.statusLine = m.renderStatusError("Item has a single paragraph, nothing to reflow")
+		return m, nil
+	}
+
+	cur.SetCollapsed(false, false)
+	m.updateTextInput(cur)
+	m.textInput.CursorEnd()
+
+	m.statusLine = ""
+	return m, nil
+}
+
+func (m *Outline) groupByTags() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+
+	cur := m.workspace.Cursor()
+	groups := cur.GroupByTags()
+	if groups == nil {
+		m.statusLine = m.renderStatusError("Item has no tagged children")
+		return m, nil
+	}
+
+	cur.SetCollapsed(false, false)
+
+	// the items are grouped by their first tag only
+	tagged := 0
+	for _, g := range groups {
+		for c := g.Head(); c != nil; c = c.Next() {
+			if len(c.Tags()) > 0 {
+				tagged++
+			}
+		}
+	}
+
+	m.statusLine = ""
+	if tagged > 0 {
+		m.statusLine = m.renderStatusMessage(fmt.Sprintf("Grouped by the first tag, items with more tags: %d", tagged))
+	}
+	return m, nil
+}
+
+func (m *Outline) ungroupToTag() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+
+	cur := m.workspace.Cursor()
+	head := cur.Head()
+	if head == nil {
+		m.statusLine = m.renderStatusError("Item has no children to ungroup")
+		return m, nil
+	}
+
+	cur.UngroupToTag()
+
+	m.statusLine = ""
+	return m.moveCursor(head)
+}
+
+func (m *Outline) flatten() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+
+	if !m.workspace.Cursor().Flatten() {
+		m.statusLine = m.renderStatusError("Item has no nested children to flatten")
+		return m, nil
+	}
+
+	m.statusLine = ""
+	return m, nil
+}
+
+// mergeIntoParent merges the cursor item into its parent and moves the
+// cursor to the parent.
+func (m *Outline) mergeIntoParent() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+
+	// the parent of the top-level items is either the real root or
+	// the zoomed-in item, none of them is displayed as a row
+	cur := m.workspace.Cursor()
+	parent := cur.Parent()
+	if parent == m.workspace.Root() {
+		m.statusLine = m.renderStatusError("Item has no parent to merge into")
+		return m, nil
+	}
+
+	cur.MergeIntoParent()
+
+	m.statusLine = ""
+	return m.moveCursor(parent)
+}
+
+// joinNext joins the item on the next displayed row into the cursor
+// item, keeping the cursor in place.
+func (m *Outline) joinNext() (tea.Model, tea.Cmd) {
+	cur := m.workspace.Cursor()
+	pos := len([]rune(cur.Title()))
+
+	next := m.nextDisplayedRow(cur)
+	if next == nil {
+		m.statusLine = m.renderStatusError("Item has no next row to join")
+		return m, nil
+	}
+
+	cur.Join(next)
+
+	// the text cursor stays at the end of the original title
+	m.updateTextInput(cur)
+	m.textInput.SetCursor(pos)
+
+	m.statusLine = ""
+	return m, nil
+}
+
+// archiveCompleted moves the completed siblings of the cursor item to
+// the archive, and their completed descendants if recursive is true.
+func (m *Outline) archiveCompleted(recursive bool) (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+
+	cur := m.workspace.Cursor()
+	parent := cur.Parent()
+
+	archive, n := parent.ArchiveCompleted(recursive)
+	if n == 0 {
+		m.statusLine = m.renderStatusError("No completed items to archive")
+		return m, nil
+	}
+
+	m.statusLine = m.renderStatusMessage(fmt.Sprintf("%d archived", n))
+
+	if !cur.IsDescendantOf(archive) {
+		return m, nil
+	}
+
+	// the cursor item was archived
+	if head := parent.Head(); head != nil && head != archive {
+		return m.moveCursor(head)
+	}
+	if parent != m.workspace.Root() {
+		return m.moveCursor(parent)
+	}
+
+	return m.reveal(archive)
+}
+
+// sortChildren sorts the cursor children alphabetically, ignoring the
+// case, and their descendants if recursive is true.
+func (m *Outline) sortChildren(recursive bool) (tea.Model, tea.Cmd) {
+	byTitle := func(a, b *data.Item) bool {
+		return strings.ToLower(a.Title()) < strings.ToLower(b.Title())
+	}
+
+	var sort func(item *data.Item)
+	sort = func(item *data.Item) {
+		item.SortChildren(byTitle)
+		if !recursive {
+			return
+		}
+
+		for c := item.Head(); c != nil; c = c.Next() {
+			sort(c)
+		}
+	}
+	sort(m.workspace.Cursor())
+
+	return m, nil
+}
+
+func (m *Outline) splitSiblings() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+
+	if first, _ := m.workspace.Cursor().SplitSiblings(); first == nil {
+		m.statusLine = m.renderStatusError("Item has no siblings above to split off")
+		return m, nil
+	}
+
+	m.statusLine = ""
+	return m, nil
+}
+
 func (m *Outline) save() (tea.Model, tea.Cmd) {
 	m.saveCurrentTitle()
 
 	err := m.workspace.Save()
-	if err != nil {
-		m.statusLine = styleStatusLineError.Render(err.Error())
+	if errors.Is(err, data.ErrChangedOnDisk) {
+		return m.promptFileChanged(), nil
+	} else if err != nil {
+		m.statusLine = m.renderStatusError(err.Error())
 	} else {
-		m.statusLine = styleStatusLineMessage.Render("Saved!")
+		m.statusLine = m.renderStatusMessage("Saved!")
 	}
 
 	return m, nil
@@ -339,47 +965,105 @@ func (m *Outline) resetStatusLineMessage() (tea.Model, tea.Cmd) {
 func (m *Outline) updateWindowSize(msg tea.WindowSizeMsg) {
 	m.windowWidth = msg.Width
 	m.windowHeight = msg.Height
+	if m.centerPending {
+		m.centerPending = false
+		m.centerCursor()
+	}
+	m.scrollToCursor()
 }
 
 func (m *Outline) Init() tea.Cmd {
-	return nil
+	return tea.Batch(scheduleAutosave(), m.watchWorkspace())
 }
 
 func (m *Outline) Update(message tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m.fileChanged(m)
 	case tea.WindowSizeMsg:
 		m.updateWindowSize(msg)
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
 
 	case tea.KeyMsg:
-		switch msg.Type {
-		case tea.KeyCtrlX:
+		m.lastKeyAt = m.now()
+		if m.autosaved {
+			m.autosaved = false
+			m.statusLine = ""
+		}
+
+		switch m.keys.global.match(msg) {
+		case "commandMode":
 			m.statusLine = m.commandMode.statusLine()
 			return m.commandMode, nil
-		case tea.KeyCtrlC:
+		case "itemMode":
 			m.statusLine = m.itemMode.statusLine()
 			return m.itemMode, nil
-		case tea.KeyEsc:
+		case "help":
+			return m.openHelp()
+		case "cancel":
+			m.clearSelection()
+			if m.capture != nil {
+				m.resetStatusLineMessage()
+				return m.returnFromCapture()
+			}
 			return m.resetStatusLineMessage()
-		case tea.KeyCtrlUp:
+		case "cursorUp":
 			return m.cursorUp()
-		case tea.KeyCtrlDown:
+		case "cursorDown":
 			return m.cursorDown()
-		case tea.KeyCtrlLeft:
+		case "cursorToParent":
 			return m.cursorToParent()
-		case tea.KeyCtrlRight:
+		case "cursorToTail":
 			return m.cursorToTail()
-		case tea.KeyCtrlShiftUp:
-			return m.moveRowUp()
-		case tea.KeyCtrlShiftDown:
-			return m.moveRowDown()
-		case tea.KeyCtrlShiftRight:
-			return m.demoteRow()
-		case tea.KeyCtrlShiftLeft:
-			return m.promoteRow()
-		case tea.KeyTab:
-			return m.addSibling()
-		case tea.KeyShiftTab:
-			return m.addChild()
+		case "pageUp":
+			return m.cursorPage(false)
+		case "pageDown":
+			return m.cursorPage(true)
+		case "cursorToFirst":
+			return m.cursorToFirst()
+		case "cursorToLast":
+			return m.cursorToLast()
+		case "nextSameStatus":
+			return m.jumpToStatus(m.workspace.Cursor().Status(), true)
+		case "prevSameStatus":
+			return m.jumpToStatus(m.workspace.Cursor().Status(), false)
+		case "moveUp":
+			return m.do((*Outline).moveRowUp)
+		case "moveDown":
+			return m.do((*Outline).moveRowDown)
+		case "moveToTop":
+			return m.do((*Outline).moveRowToTop)
+		case "moveToBottom":
+			return m.do((*Outline).moveRowToBottom)
+		case "demote":
+			return m.do((*Outline).demoteRow)
+		case "promote":
+			return m.do((*Outline).promoteRow)
+		case "addSibling":
+			return m.do((*Outline).addSibling)
+		case "addChild":
+			return m.do((*Outline).addChild)
+		case "splitItem":
+			return m.do((*Outline).splitItem)
+		case "cycleStatus":
+			return m.do(cycleStatusAction(true))
+		case "cycleStatusBack":
+			return m.do(cycleStatusAction(false))
+		case "repeat":
+			return m.repeatLastAction()
+		case "scratch":
+			return m.openScratch()
+		case "timestamp":
+			return m.insertTimestamp()
+		case "select":
+			return m.toggleSelected()
+		case "undo":
+			return m.undo()
+		case "redo":
+			return m.redo()
 		default:
 			return m.updateRow(message)
 		}
@@ -391,8 +1075,10 @@ func (m *Outline) Update(message tea.Msg) (tea.Model, tea.Cmd) {
 func (m *Outline) renderBreadcrumbs() string {
 	breadcrumbs := lipgloss.JoinHorizontal(
 		lipgloss.Top,
-		styleBreadcrumbs.Render(m.breadcrumbs()),
-		styleBreadcrumbHighlited.Render(m.workspace.Root().Title()),
+		m.styles.breadcrumbs.Render(m.breadcrumbs()),
+		m.renderUnsavedIndicator(),
+		m.styles.breadcrumbHighlited.Render(m.workspace.Root().Title()),
+		m.renderFilterIndicator(),
 	)
 
 	breadcrumbs = runewidth.Truncate(breadcrumbs, m.windowWidth-2, "...")
@@ -404,7 +1090,7 @@ func (m *Outline) renderBreadcrumbs() string {
 	)
 
 	breadcrumbs = lipgloss.PlaceVertical(
-		3,
+		listTop,
 		lipgloss.Center,
 		breadcrumbs,
 	)
@@ -412,45 +1098,75 @@ func (m *Outline) renderBreadcrumbs() string {
 	return breadcrumbs
 }
 
+// renderUnsavedIndicator returns the marker put before the root title
+// while there are unsaved changes, or an empty string.
+func (m *Outline) renderUnsavedIndicator() string {
+	if !m.unsaved() {
+		return ""
+	}
+
+	return m.styles.unsavedIndicator.Render(unsavedIndicator)
+}
+
 func (m *Outline) renderItemEntry(item *data.Item) string {
 	bullet := getBullet(item)
-	bullet = styleBullet[(item.Depth()-1)%len(styleBullet)].Render(bullet)
+	bullet = m.styles.bullet[(item.Depth()-1)%len(m.styles.bullet)].Render(bullet)
 
-	status := getStatus(item)
+	status := m.getStatus(item)
+	priority := m.getPriority(item)
 
 	padding := getLinePadding(item)
 
+	var marker string
+	itemStyle := m.getItemStyle(item)
+	if item.IsOverdue(m.now()) {
+		itemStyle = m.styles.getItemOverdueStyle().Inherit(itemStyle)
+		marker = getOverdueMarker()
+	}
+	if _, ok := m.selection[item]; ok {
+		itemStyle = m.styles.itemSelected.Inherit(itemStyle)
+	}
+
 	var title string
 	if m.workspace.Cursor() == item {
-		m.textInput.TextStyle = getItemStyle(item)
+		m.textInput.TextStyle = itemStyle
 		title = m.textInput.View()
 	} else {
-		title = item.Title()
-
-		maxTitleWidth := m.getMaxTitleWidth(padding)
-		title = runewidth.Truncate(title, maxTitleWidth, "...")
-		title = getItemStyle(item).Render(title)
+		lines := m.titleLines(item)
+		for i, l := range lines {
+			lines[i] = m.renderTitleLine(l, itemStyle)
+		}
+		title = strings.Join(lines, "\n")
 	}
 
-	var todoStats string
-	if completed, total := item.ToDoStats(); completed != 0 || total != 0 {
-		todoStats = fmt.Sprintf("(%d/%d)", completed, total)
-		todoStats = styleTodoStats.Render(todoStats)
-	}
+	meta := m.getItemMeta(item)
 
-	itemRow := lipgloss.JoinHorizontal(lipgloss.Top, bullet, status, title, todoStats)
+	itemRow := lipgloss.JoinHorizontal(lipgloss.Top, bullet, status, priority, marker, title, meta)
 	itemRow = lipgloss.PlaceHorizontal(
 		m.windowWidth-padding,
 		lipgloss.Left,
 		itemRow,
 	)
 
+	if m.config.IndentGuides {
+		guides := m.renderGuides(item, lipgloss.Height(itemRow))
+		itemRow = lipgloss.JoinHorizontal(lipgloss.Top, guides, itemRow)
+	}
+
 	return itemRow
 }
 
 func (m *Outline) renderItemList() string {
+	// folding and zooming change the rows without moving the cursor
+	lines := m.visibleLines(m.scrollToCursor())
+
 	var itemEntries []string
-	for _, item := range m.workspace.Root().DisplayedChildren() {
+	for _, item := range lines {
+		if item == nil {
+			itemEntries = append(itemEntries, m.styles.separator.Render(strings.Repeat(separatorChar, m.windowWidth)))
+			continue
+		}
+
 		itemEntry := m.renderItemEntry(item)
 		itemEntries = append(itemEntries, itemEntry)
 	}
@@ -465,11 +1181,26 @@ func (m *Outline) renderItemList() string {
 	return items
 }
 
-func (m *Outline) renderStatusLine() string {
-	return lipgloss.PlaceHorizontal(m.windowWidth, lipgloss.Top, m.statusLine)
+func (m *Outline) renderStatusLine(statusLine string) string {
+	indicator := m.scrollIndicator()
+	if gap := m.windowWidth - lipgloss.Width(statusLine) - len(indicator); indicator != "" && gap > 0 {
+		statusLine += strings.Repeat(" ", gap) + m.styles.scrollIndicator.Render(indicator)
+	}
+
+	return lipgloss.PlaceHorizontal(m.windowWidth, lipgloss.Top, statusLine)
 }
 
 func (m *Outline) View() string {
+	statusLine := m.statusLine
+	if statusLine == "" && len(m.selection) > 0 {
+		statusLine = m.renderStatusMessage(summarizeSelection(m.selection))
+	}
+
+	return m.renderView(statusLine)
+}
+
+// renderView renders the outline with the provided status line.
+func (m *Outline) renderView(statusLine string) string {
 	// Wait for the window size to be set
 	if m.windowWidth == 0 || m.windowHeight == 0 {
 		return ""
@@ -479,7 +1210,7 @@ func (m *Outline) View() string {
 		lipgloss.Left,
 		m.renderBreadcrumbs(),
 		m.renderItemList(),
-		m.renderStatusLine(),
+		m.renderStatusLine(statusLine),
 	)
 }
 
@@ -487,25 +1218,70 @@ type commandMode struct {
 	*Outline
 }
 
-func (commandMode) statusLine() string {
-	return "command: [q]uit without saving  [s]ave file"
+func (m commandMode) statusLine() string {
+	return m.keys.command.menu()
 }
 
 func (m commandMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m.fileChanged(m)
 	case tea.WindowSizeMsg:
 		m.updateWindowSize(msg)
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "esc":
+		if msg.Type == tea.KeyEsc {
 			m.Outline.statusLine = ""
 			return m.Outline, nil
-		case "q":
+		}
+
+		switch m.keys.command.match(msg) {
+		case "quit":
+			return m.quit()
+		case "save":
 			m.Outline.statusLine = ""
-			return m.Outline, tea.Quit
-		case "s":
+			return m.save()
+		case "reload":
+			return m.confirmReload()
+		case "readSubtree":
+			return m.openReader()
+		case "goTo":
+			return m.openPalette()
+		case "openDocument":
+			return m.openDocumentPicker()
+		case "trash":
+			return m.openTrash()
+		case "dailyNote":
+			return m.openDailyNote()
+		case "dueSoon":
+			return m.openDueView()
+		case "agenda":
+			return m.openAgenda()
+		case "viewMode":
+			m.Outline.statusLine = m.Outline.viewMode.statusLine()
+			return m.Outline.viewMode, nil
+		case "search":
+			return m.startSearch()
+		case "goToMatch":
+			return m.promptMatchNumber()
+		case "nextWithStatus":
+			m.Outline.statusLine = m.keys.statusJump.menu()
+			return statusJumpMode{Outline: m.Outline, forward: true}, nil
+		case "prevWithStatus":
+			m.Outline.statusLine = m.keys.statusJump.menu()
+			return statusJumpMode{Outline: m.Outline, forward: false}, nil
+		case "importCSV":
+			return m.promptImportCSV()
+		case "exportMarkdown":
+			return m.exportMarkdown()
+		case "exportSubtree":
+			return m.promptExportSubtree()
+		case "stats":
+			return m.openStats()
+		case "commandHelp":
 			m.Outline.statusLine = ""
-			m.save()
+			return m.openHelp()
 		default:
 			return m, nil
 		}
@@ -518,36 +1294,121 @@ type itemMode struct {
 	*Outline
 }
 
-func (itemMode) statusLine() string {
-	return "item: [d]elete  [D]elete recursive  [f]old  [F]old recursive  change [s]tatus  [z]oom in  [Z]oom out"
+func (m itemMode) statusLine() string {
+	return m.keys.item.menu()
 }
 
 func (m itemMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m.fileChanged(m)
 	case tea.WindowSizeMsg:
 		m.updateWindowSize(msg)
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "esc":
+		if msg.Type == tea.KeyEsc {
 			m.Outline.statusLine = ""
 			return m.Outline, nil
-		case "d":
-			return m.deleteItem(false)
-		case "D":
-			return m.deleteItem(true)
-		case "f":
+		}
+
+		switch m.keys.item.match(msg) {
+		case "completeAncestors":
+			m.Outline.statusLine = ""
+			return m.do((*Outline).completeWithAncestors)
+		case "toggleCase":
+			m.Outline.statusLine = ""
+			return m.do((*Outline).cycleTitleCase)
+		case "deleteItem":
+			return m.do(deleteItemAction(false))
+		case "deleteRecursive":
+			return m.do(deleteItemAction(true))
+		case "fold":
 			return m.toggleItemFolded(false)
-		case "F":
+		case "foldRecursive":
 			return m.toggleItemFolded(true)
-		case "s":
+		case "collapseAll":
+			m.Outline.statusLine = ""
+			return m.setAllCollapsed(true)
+		case "expandAll":
+			m.Outline.statusLine = ""
+			return m.setAllCollapsed(false)
+		case "expandToLevel":
+			// the level is the digit key itself
+			n, err := strconv.Atoi(msg.String())
+			if err != nil || n < 1 {
+				return m, nil
+			}
+			m.Outline.statusLine = ""
+			return m.expandToLevel(n)
+		case "cut":
+			m.Outline.statusLine = ""
+			return m.do((*Outline).cutItem)
+		case "flatten":
+			return m.do((*Outline).flatten)
+		case "link":
+			return m.openLinkPalette()
+		case "insertTemplate":
+			return m.openTemplatePicker()
+		case "joinNext":
+			return m.do((*Outline).joinNext)
+		case "setMark":
+			return m.readMark(true)
+		case "jumpToMark":
+			return m.readMark(false)
+		case "mergeIntoParent":
+			return m.do((*Outline).mergeIntoParent)
+		case "followLink":
+			m.Outline.statusLine = ""
+			return m.followLink()
+		case "toggleLeaf":
+			m.Outline.statusLine = ""
+			return m.do((*Outline).toggleLeaf)
+		case "editNote":
+			return m.editNote()
+		case "priorityMode":
+			m.Outline.statusLine = m.Outline.itemPriorityMode.statusLine()
+			return m.Outline.itemPriorityMode, nil
+		case "reflow":
+			return m.do((*Outline).reflowItem)
+		case "statusMode":
 			m.Outline.statusLine = m.Outline.itemStatusMode.statusLine()
 			return m.Outline.itemStatusMode, nil
-		case "z":
+		case "tagsToGroups":
+			return m.do((*Outline).groupByTags)
+		case "groupToTag":
+			return m.do((*Outline).ungroupToTag)
+		case "sortChildren":
+			m.Outline.statusLine = ""
+			return m.do(sortChildrenAction(false))
+		case "sortRecursive":
+			m.Outline.statusLine = ""
+			return m.do(sortChildrenAction(true))
+		case "splitList":
+			return m.do((*Outline).splitSiblings)
+		case "duplicate":
+			m.Outline.statusLine = ""
+			return m.do((*Outline).duplicateItem)
+		case "copy":
+			return m.copyItem()
+		case "xml":
+			return m.openXMLView()
+		case "paste":
+			m.Outline.statusLine = ""
+			return m.do((*Outline).pasteItem)
+		case "zoomIn":
 			m.Outline.statusLine = ""
 			m.zoomIn()
-		case "Z":
+		case "zoomOut":
 			m.Outline.statusLine = ""
 			m.zoomOut()
+		case "zoomHome":
+			m.Outline.statusLine = ""
+			m.zoomToRealRoot()
+		case "archive":
+			return m.do(archiveCompletedAction(false))
+		case "archiveRecursive":
+			return m.do(archiveCompletedAction(true))
 		default:
 			return m, nil
 		}
@@ -558,39 +1419,158 @@ func (m itemMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
 
 type itemStatusMode struct {
 	*Outline
+
+	// whether the status is set to the descendants of the item too
+	recursive bool
 }
 
-func (itemStatusMode) statusLine() string {
-	return "item status: [n]one  [t]odo  [d]one  [c]canceled  [w]aiting  [s]cheduled"
+func (m itemStatusMode) statusLine() string {
+	if m.recursive {
+		return "recursive " + m.keys.itemStatus.menu()
+	}
+
+	return m.keys.itemStatus.menu()
+}
+
+// setStatus sets the status of the cursor item, and of its descendants
+// if the mode is recursive.
+func (m itemStatusMode) setStatus(s data.Status) (tea.Model, tea.Cmd) {
+	m.Outline.statusLine = ""
+	if m.recursive {
+		return m.do(setStatusRecursiveAction(s))
+	}
+
+	return m.do(setStatusAction(s))
 }
 
 func (m itemStatusMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m.fileChanged(m)
 	case tea.WindowSizeMsg:
 		m.updateWindowSize(msg)
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "esc":
+		if msg.Type == tea.KeyEsc {
 			m.Outline.statusLine = ""
 			return m.Outline, nil
-		case "n":
+		}
+
+		switch m.keys.itemStatus.match(msg) {
+		case "statusNone":
+			return m.setStatus(data.StatusNone)
+		case "statusToDo":
+			return m.setStatus(data.StatusToDo)
+		case "statusDone":
+			return m.setStatus(data.StatusDone)
+		case "statusCanceled":
+			return m.setStatus(data.StatusCanceled)
+		case "statusWaiting":
+			return m.setStatus(data.StatusWaiting)
+		case "statusRecursive":
+			m.recursive = true
+			m.Outline.statusLine = m.statusLine()
+			return m, nil
+		case "statusScheduled":
+			// the descendants are scheduled without a date
+			if m.recursive {
+				return m.setStatus(data.StatusScheduled)
+			}
+			return m.promptSchedule()
+		default:
+			return m, nil
+		}
+	}
+
+	return m.Outline, nil
+}
+
+type itemPriorityMode struct {
+	*Outline
+}
+
+func (m itemPriorityMode) statusLine() string {
+	return m.keys.itemPriority.menu()
+}
+
+func (m itemPriorityMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m.fileChanged(m)
+	case tea.WindowSizeMsg:
+		m.updateWindowSize(msg)
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyEsc {
+			m.Outline.statusLine = ""
+			return m.Outline, nil
+		}
+
+		switch m.keys.itemPriority.match(msg) {
+		case "priorityNone":
 			m.Outline.statusLine = ""
-			m.Outline.workspace.Cursor().SetStatus(data.StatusNone)
-		case "t":
+			return m.do(setPriorityAction(data.PriorityNone))
+		case "priorityA":
+			m.Outline.statusLine = ""
+			return m.do(setPriorityAction(data.PriorityA))
+		case "priorityB":
+			m.Outline.statusLine = ""
+			return m.do(setPriorityAction(data.PriorityB))
+		case "priorityC":
+			m.Outline.statusLine = ""
+			return m.do(setPriorityAction(data.PriorityC))
+		default:
+			return m, nil
+		}
+	}
+
+	return m.Outline, nil
+}
+
+type viewMode struct {
+	*Outline
+}
+
+func (m viewMode) statusLine() string {
+	return m.keys.view.menu()
+}
+
+func (m viewMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m.fileChanged(m)
+	case tea.WindowSizeMsg:
+		m.updateWindowSize(msg)
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyEsc {
+			m.Outline.statusLine = ""
+			return m.Outline, nil
+		}
+
+		switch m.keys.view.match(msg) {
+		case "toggleChildCount":
 			m.Outline.statusLine = ""
-			m.Outline.workspace.Cursor().SetStatus(data.StatusToDo)
-		case "d":
+			m.showChildCount = !m.showChildCount
+		case "toggleAutoExpand":
 			m.Outline.statusLine = ""
-			m.Outline.workspace.Cursor().SetStatus(data.StatusDone)
-		case "c":
+			m.autoExpand = !m.autoExpand
+		case "toggleHideCanceled":
 			m.Outline.statusLine = ""
-			m.Outline.workspace.Cursor().SetStatus(data.StatusCanceled)
-		case "w":
+			m.hideCanceled = !m.hideCanceled
+			return m.revealCursor()
+		case "toggleSeparators":
 			m.Outline.statusLine = ""
-			m.Outline.workspace.Cursor().SetStatus(data.StatusWaiting)
-		case "s":
+			m.showSeparators = !m.showSeparators
+		case "toggleWrap":
 			m.Outline.statusLine = ""
-			m.Outline.workspace.Cursor().SetStatus(data.StatusScheduled)
+			m.wrapTitles = !m.wrapTitles
+		case "filterMode":
+			m.Outline.statusLine = m.Outline.statusFilterMode.statusLine()
+			return m.Outline.statusFilterMode, nil
 		default:
 			return m, nil
 		}
@@ -0,0 +1,575 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/config"
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+// newTestOutline returns an outline over a workspace with three root
+// children, the cursor placed on the first one.
+func newTestOutline(t *testing.T) (*Outline, *data.Item, *data.Item, *data.Item) {
+	t.Helper()
+
+	w := data.NewWorkspace(t.TempDir(), "Root")
+
+	a := w.NewItem("ChildA")
+	b := w.NewItem("ChildB")
+	c := w.NewItem("ChildC")
+
+	w.Root().Append(a)
+	w.Root().Append(b)
+	w.Root().Append(c)
+	w.SetCursor(a)
+
+	m, err := NewOutline(w, config.Default())
+	require.NoError(t, err)
+
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	return m, a, b, c
+}
+
+func TestZoomToRealRoot(t *testing.T) {
+	t.Run("AlreadyAtRealRoot", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+		root := m.workspace.Root()
+
+		press(m, key(tea.KeyCtrlC), runes("H"))
+
+		assert.Same(t, root, m.workspace.Root())
+		assert.Same(t, a, m.workspace.Cursor())
+	})
+
+	t.Run("DeeplyZoomed", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+		root := m.workspace.Root()
+
+		a.Append(b)
+		b.Append(c)
+		a.SetCollapsed(true, false)
+
+		m.workspace.SetRoot(b)
+		m.moveCursor(c)
+
+		press(m, key(tea.KeyCtrlC), runes("H"))
+
+		assert.Same(t, root, m.workspace.Root())
+		assert.Same(t, b, m.workspace.Cursor())
+		assert.False(t, a.Collapsed())
+	})
+}
+
+func TestInsertTimestamp(t *testing.T) {
+	m, a, _, _ := newTestOutline(t)
+	m.now = func() time.Time {
+		return time.Date(2025, 6, 14, 10, 30, 0, 0, time.Local)
+	}
+
+	m.textInput.SetValue("Ёжик log")
+	m.textInput.SetCursor(5)
+
+	press(m, key(tea.KeyCtrlT), runes(" "))
+	assert.Equal(t, "Ёжик 2025-06-14 log", m.textInput.Value())
+
+	m.config.TimestampFormat = "15:04"
+	m.textInput.CursorEnd()
+
+	press(m, runes(" "), key(tea.KeyCtrlT))
+	assert.Equal(t, "Ёжик 2025-06-14 log 10:30", m.textInput.Value())
+
+	m.saveCurrentTitle()
+	assert.Equal(t, "Ёжик 2025-06-14 log 10:30", a.Title())
+}
+
+func TestSplitSiblings(t *testing.T) {
+	m, a, b, c := newTestOutline(t)
+
+	press(m, key(tea.KeyCtrlC), runes("S"))
+	assert.Same(t, m.workspace.Root(), a.Parent())
+
+	press(m, key(tea.KeyCtrlDown), key(tea.KeyCtrlC), runes("S"))
+
+	first, second := a.Parent(), b.Parent()
+	assert.Equal(t, "Part 1", first.Title())
+	assert.Equal(t, "Part 2", second.Title())
+	assert.Same(t, second, c.Parent())
+	assert.Same(t, b, m.workspace.Cursor())
+	assert.Equal(t, "ChildB", m.textInput.Value())
+}
+
+func TestCompleteWithAncestors(t *testing.T) {
+	m, a, b, c := newTestOutline(t)
+	a.Append(b)
+	b.Append(c)
+	a.SetStatus(data.StatusToDo)
+	c.SetStatus(data.StatusToDo)
+
+	m.workspace.SetRoot(a)
+	m.moveCursor(c)
+
+	press(m, key(tea.KeyCtrlC), runes("a"))
+	assert.Equal(t, data.StatusDone, c.Status())
+	assert.Equal(t, data.StatusNone, b.Status())
+	assert.Equal(t, data.StatusToDo, a.Status())
+
+	m.config.CompleteUnstatused = true
+	press(m, key(tea.KeyCtrlR))
+	assert.Equal(t, data.StatusDone, b.Status())
+	assert.Equal(t, data.StatusToDo, a.Status())
+}
+
+func TestToggleLeaf(t *testing.T) {
+	t.Run("LeafToContainer", func(t *testing.T) {
+		m, a, b, _ := newTestOutline(t)
+
+		press(m, key(tea.KeyCtrlC), runes("l"))
+
+		child := a.Head()
+		require.NotNil(t, child)
+		assert.Same(t, child, a.Tail())
+		assert.Equal(t, "", child.Title())
+		assert.Same(t, child, m.workspace.Cursor())
+		assert.Same(t, b, a.Next())
+	})
+
+	t.Run("ContainerToLeaf", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+		child := m.workspace.NewItem("")
+		a.Append(child)
+
+		press(m, key(tea.KeyCtrlC), runes("l"))
+
+		assert.Nil(t, a.Head())
+		assert.Nil(t, child.Parent())
+		assert.Same(t, a, m.workspace.Cursor())
+	})
+
+	t.Run("NonEmptyChild", func(t *testing.T) {
+		m, a, b, _ := newTestOutline(t)
+		a.Append(b)
+
+		press(m, key(tea.KeyCtrlC), runes("l"))
+
+		assert.Same(t, a, b.Parent())
+		assert.Contains(t, m.statusLine, "single empty")
+	})
+
+	t.Run("SeveralEmptyChildren", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+		a.Append(m.workspace.NewItem(""))
+		a.Append(m.workspace.NewItem(""))
+
+		press(m, key(tea.KeyCtrlC), runes("l"))
+
+		assert.Equal(t, 2, a.ChildCount())
+	})
+}
+
+func TestSortChildren(t *testing.T) {
+	m, a, b, c := newTestOutline(t)
+	w := m.workspace
+
+	banana := w.NewItem("banana")
+	a.Append(banana)
+	a.Append(w.NewItem("Apple"))
+	a.Append(w.NewItem("cherry"))
+	banana.Append(b)
+	banana.Append(c)
+	b.SetTitle("z")
+	c.SetTitle("y")
+
+	children := func(item *data.Item) []string {
+		var titles []string
+		for c := item.Head(); c != nil; c = c.Next() {
+			titles = append(titles, c.Title())
+		}
+		return titles
+	}
+
+	press(m, key(tea.KeyCtrlC), runes("o"))
+	assert.Equal(t, []string{"Apple", "banana", "cherry"}, children(a))
+	assert.Equal(t, []string{"z", "y"}, children(banana))
+	assert.Empty(t, m.statusLine)
+
+	press(m, key(tea.KeyCtrlZ))
+	assert.Equal(t, []string{"banana", "Apple", "cherry"}, children(a))
+
+	m.moveCursor(a)
+	press(m, key(tea.KeyCtrlC), runes("O"))
+	assert.Equal(t, []string{"Apple", "banana", "cherry"}, children(a))
+	assert.Equal(t, []string{"y", "z"}, children(banana))
+}
+
+func TestSetPriority(t *testing.T) {
+	m, a, b, _ := newTestOutline(t)
+
+	press(m, key(tea.KeyCtrlC), runes("p"), runes("a"))
+	assert.Equal(t, data.PriorityA, a.Priority())
+	assert.Empty(t, m.statusLine)
+	assert.Contains(t, m.renderItemEntry(a), "[#A]")
+
+	press(m, key(tea.KeyCtrlDown), key(tea.KeyCtrlR))
+	assert.Equal(t, data.PriorityA, b.Priority())
+
+	a.SetStatus(data.StatusToDo)
+	press(m, key(tea.KeyCtrlUp), key(tea.KeyCtrlC), runes("p"), runes("n"))
+	assert.Equal(t, data.PriorityNone, a.Priority())
+	assert.Equal(t, data.StatusToDo, a.Status())
+	assert.NotContains(t, m.renderItemEntry(a), "[#")
+}
+
+func TestArchiveCompleted(t *testing.T) {
+	m, a, b, c := newTestOutline(t)
+	b.SetStatus(data.StatusDone)
+	m.moveCursor(b)
+
+	press(m, key(tea.KeyCtrlC), runes("v"))
+	assert.Contains(t, m.statusLine, "1 archived")
+
+	archive := m.workspace.Root().Tail()
+	assert.Equal(t, data.ArchiveTitle, archive.Title())
+	assert.Same(t, archive, b.Parent())
+	assert.Same(t, a, m.workspace.Cursor())
+
+	press(m, key(tea.KeyCtrlC), runes("v"))
+	assert.Contains(t, m.statusLine, "No completed items")
+
+	press(m, key(tea.KeyCtrlZ))
+	assert.Same(t, c, b.Next())
+	assert.Nil(t, archive.Parent())
+}
+
+func TestMoveRowToEdges(t *testing.T) {
+	m, a, b, c := newTestOutline(t)
+	root := m.workspace.Root()
+	m.moveCursor(b)
+
+	press(m, key(tea.KeyCtrlShiftHome))
+	assert.Same(t, b, root.Head())
+	assert.Same(t, a, b.Next())
+	assert.Same(t, b, m.workspace.Cursor())
+
+	press(m, key(tea.KeyCtrlShiftEnd))
+	assert.Same(t, b, root.Tail())
+	assert.Same(t, c, b.Prev())
+
+	press(m, key(tea.KeyCtrlZ))
+	assert.Same(t, b, root.Head())
+}
+
+func TestSetAllCollapsed(t *testing.T) {
+	m, a, b, c := newTestOutline(t)
+	nested := m.workspace.NewItem("Nested")
+	deeper := m.workspace.NewItem("Deeper")
+	a.Append(nested)
+	nested.Append(deeper)
+	c.Append(m.workspace.NewItem("Other"))
+	m.moveCursor(deeper)
+	require.Len(t, m.workspace.Root().DisplayedChildren(), 6)
+
+	press(m, key(tea.KeyCtrlC), runes("C"))
+	assert.Equal(t, []*data.Item{a, b, c}, m.workspace.Root().DisplayedChildren())
+	assert.True(t, nested.Collapsed())
+	assert.Same(t, a, m.workspace.Cursor())
+
+	press(m, key(tea.KeyCtrlC), runes("E"))
+	assert.Len(t, m.workspace.Root().DisplayedChildren(), 6)
+	assert.Same(t, a, m.workspace.Cursor())
+
+	// a zoomed view only changes the zoomed subtree
+	m.workspace.SetRoot(a)
+	c.SetCollapsed(true, false)
+	press(m, key(tea.KeyCtrlC), runes("E"))
+	assert.True(t, c.Collapsed())
+	press(m, key(tea.KeyCtrlC), runes("C"))
+	assert.Equal(t, []*data.Item{nested}, a.DisplayedChildren())
+}
+
+func TestExpandToLevel(t *testing.T) {
+	m, a, b, c := newTestOutline(t)
+	nested := m.workspace.NewItem("Nested")
+	deeper := m.workspace.NewItem("Deeper")
+	a.Append(b)
+	b.Append(nested)
+	nested.Append(deeper)
+	m.moveCursor(deeper)
+
+	press(m, key(tea.KeyCtrlC), runes("2"))
+	assert.Equal(t, []*data.Item{a, b, c}, m.workspace.Root().DisplayedChildren())
+	assert.Same(t, b, m.workspace.Cursor())
+
+	// the levels are counted from the view root
+	m.workspace.SetRoot(a)
+	press(m, key(tea.KeyCtrlC), runes("2"))
+	assert.Equal(t, []*data.Item{b, nested}, a.DisplayedChildren())
+	assert.Same(t, b, m.workspace.Cursor())
+}
+
+func TestRestoredBackupWarning(t *testing.T) {
+	dir := t.TempDir()
+	w, err := data.LoadWorkspace(dir, nil)
+	require.NoError(t, err)
+	w.SetBackups(false)
+	w.Cursor().SetTitle("Saved")
+	require.NoError(t, w.Save())
+
+	p := filepath.Join(dir, "workspace.xml")
+	raw, err := os.ReadFile(p)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(p+".bak.100", raw, 0600))
+	require.NoError(t, os.WriteFile(p, raw[:len(raw)/2], 0600))
+
+	w, err = data.LoadWorkspace(dir, nil)
+	require.NoError(t, err)
+
+	m, err := NewOutline(w, config.Default())
+	require.NoError(t, err)
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	view := ansi.Strip(m.View())
+	assert.Contains(t, view, "Workspace file is corrupt, restored the backup workspace.xml.bak.100")
+	assert.Contains(t, view, "Saved")
+}
+
+func TestCycleStatus(t *testing.T) {
+	m, a, _, _ := newTestOutline(t)
+
+	model := press(m, key(tea.KeyF2))
+	assert.Same(t, m, model)
+	assert.Equal(t, data.StatusToDo, a.Status())
+
+	press(m, key(tea.KeyF2))
+	assert.Equal(t, data.StatusDone, a.Status())
+
+	press(m, key(tea.KeyF14))
+	assert.Equal(t, data.StatusToDo, a.Status())
+
+	// the cycling is undone and repeated step by step
+	press(m, key(tea.KeyCtrlZ))
+	assert.Equal(t, data.StatusDone, a.Status())
+	press(m, key(tea.KeyCtrlR))
+	assert.Equal(t, data.StatusToDo, a.Status())
+}
+
+func TestSetStatusRecursive(t *testing.T) {
+	m, a, b, c := newTestOutline(t)
+	a.Append(b)
+
+	model := press(m, key(tea.KeyCtrlC), runes("s"), runes("r"))
+	assert.Contains(t, model.View(), "recursive item status: ")
+
+	press(model, runes("d"))
+	assert.Equal(t, data.StatusDone, a.Status())
+	assert.Equal(t, data.StatusDone, b.Status())
+	assert.Equal(t, data.StatusNone, c.Status())
+
+	// the plain status change leaves the children alone
+	press(m, key(tea.KeyCtrlC), runes("s"), runes("t"))
+	assert.Equal(t, data.StatusToDo, a.Status())
+	assert.Equal(t, data.StatusDone, b.Status())
+}
+
+func TestFlatten(t *testing.T) {
+	m, a, b, c := newTestOutline(t)
+	a.Append(b)
+	b.Append(c)
+
+	press(m, key(tea.KeyCtrlC), runes("e"))
+	assert.Equal(t, []*data.Item{b, c}, a.DisplayedChildren())
+	assert.Same(t, a, m.workspace.Cursor())
+
+	press(m, key(tea.KeyCtrlC), runes("e"))
+	assert.Contains(t, m.statusLine, "Item has no nested children to flatten")
+}
+
+func TestMergeIntoParent(t *testing.T) {
+	t.Run("Merge", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+		a.Append(b)
+		b.Append(c)
+		m.moveCursor(b)
+
+		press(m, key(tea.KeyCtrlC), runes("m"))
+		assert.Equal(t, "ChildA ChildB", a.Title())
+		assert.Equal(t, []*data.Item{c}, a.DisplayedChildren())
+		assert.Same(t, a, m.workspace.Cursor())
+		assert.Equal(t, "ChildA ChildB", m.textInput.Value())
+	})
+
+	t.Run("TopLevel", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+
+		press(m, key(tea.KeyCtrlC), runes("m"))
+		assert.Equal(t, []*data.Item{a, b, c}, m.workspace.Root().DisplayedChildren())
+		assert.Same(t, a, m.workspace.Cursor())
+		assert.Contains(t, m.statusLine, "Item has no parent to merge into")
+	})
+
+	t.Run("ZoomedIn", func(t *testing.T) {
+		m, a, b, _ := newTestOutline(t)
+		a.Append(b)
+		m.workspace.SetRoot(a)
+		m.moveCursor(b)
+
+		press(m, key(tea.KeyCtrlC), runes("m"))
+		assert.Equal(t, "ChildA", a.Title())
+		assert.Same(t, b, m.workspace.Cursor())
+	})
+}
+
+func TestSplitItem(t *testing.T) {
+	m, a, b, _ := newTestOutline(t)
+	a.SetStatus(data.StatusToDo)
+	a.Append(b)
+	m.textInput.SetValue("Привет, мир")
+	m.textInput.SetCursor(7)
+
+	press(m, tea.KeyMsg{Type: tea.KeyEnter, Alt: true})
+
+	next := a.Next()
+	assert.Equal(t, "Привет,", a.Title())
+	assert.Equal(t, " мир", next.Title())
+	assert.Equal(t, data.StatusNone, next.Status())
+	assert.Nil(t, next.Head())
+	assert.Equal(t, []*data.Item{b}, a.DisplayedChildren())
+
+	assert.Same(t, next, m.workspace.Cursor())
+	assert.Equal(t, " мир", m.textInput.Value())
+	assert.Equal(t, 0, m.textInput.Position())
+
+	press(m, key(tea.KeyCtrlZ))
+	assert.Equal(t, "Привет, мир", a.Title())
+	assert.Nil(t, next.Parent())
+}
+
+func TestJoinNext(t *testing.T) {
+	m, a, b, c := newTestOutline(t)
+
+	press(m, key(tea.KeyCtrlC), runes("j"))
+	assert.Equal(t, "ChildA ChildB", a.Title())
+	assert.Equal(t, []*data.Item{a, c}, m.workspace.Root().DisplayedChildren())
+	assert.Nil(t, b.Parent())
+	assert.Same(t, a, m.workspace.Cursor())
+	assert.Equal(t, "ChildA ChildB", m.textInput.Value())
+	assert.Equal(t, len("ChildA"), m.textInput.Position())
+
+	m.moveCursor(c)
+	press(m, key(tea.KeyCtrlC), runes("j"))
+	assert.Equal(t, "ChildC", c.Title())
+	assert.Contains(t, m.statusLine, "Item has no next row to join")
+
+	// the displayed child is on the next row
+	d := m.workspace.NewItem("ChildD")
+	a.Append(d)
+	m.moveCursor(a)
+	press(m, key(tea.KeyCtrlC), runes("j"))
+	assert.Equal(t, "ChildA ChildB ChildD", a.Title())
+	assert.Nil(t, d.Parent())
+	assert.Equal(t, []*data.Item{a, c}, m.workspace.Root().DisplayedChildren())
+}
+
+func TestShowCompleted(t *testing.T) {
+	m, _, b, c := newTestOutline(t)
+	b.SetStatus(data.StatusDone)
+	c.SetStatus(data.StatusDone)
+	c.SetStatus(data.StatusCanceled)
+	done := "done " + b.Completed().Format(data.DueLayout)
+
+	assert.NotContains(t, ansi.Strip(m.renderItemEntry(b)), done)
+
+	m.config.ShowCompleted = true
+	assert.Contains(t, ansi.Strip(m.renderItemEntry(b)), done)
+
+	// the canceled items keep the time, but it is not shown
+	assert.False(t, c.Completed().IsZero())
+	assert.NotContains(t, ansi.Strip(m.renderItemEntry(c)), "done ")
+}
+
+func TestIndentGuides(t *testing.T) {
+	m, a, b, c := newTestOutline(t)
+	d := m.workspace.NewItem("ChildD")
+	a.Append(d)
+
+	assert.Equal(t, m.windowWidth-getLinePadding(d), lipgloss.Width(m.renderItemEntry(d)))
+
+	m.config.IndentGuides = true
+	assert.True(t, strings.HasPrefix(ansi.Strip(m.renderItemEntry(a)), "├─ "))
+	assert.True(t, strings.HasPrefix(ansi.Strip(m.renderItemEntry(d)), "│ └─ "))
+	assert.True(t, strings.HasPrefix(ansi.Strip(m.renderItemEntry(c)), "└─ "))
+
+	// the guides take the place of the padding
+	for _, item := range []*data.Item{a, b, c, d} {
+		assert.Equal(t, m.windowWidth, lipgloss.Width(m.renderItemEntry(item)))
+	}
+
+	// the wrapped lines continue the guides
+	m.wrapTitles = true
+	b.SetTitle(strings.Repeat("word ", 30))
+	lines := strings.Split(ansi.Strip(m.renderItemEntry(b)), "\n")
+	require.Greater(t, len(lines), 1)
+	assert.True(t, strings.HasPrefix(lines[0], "├─ "))
+	assert.True(t, strings.HasPrefix(lines[1], "│  "))
+}
+
+func TestGroupByTags(t *testing.T) {
+	m, a, b, c := newTestOutline(t)
+	a.Append(b)
+	a.Append(c)
+	b.AddTag("home")
+	b.AddTag("work")
+	c.AddTag("work")
+
+	press(m, key(tea.KeyCtrlC), runes("t"))
+	assert.Contains(t, m.statusLine, "Grouped by the first tag, items with more tags: 1")
+	assert.Equal(t, "home", b.Parent().Title())
+	assert.Equal(t, "work", c.Parent().Title())
+	assert.Equal(t, []string{"work"}, b.Tags())
+
+	m.moveCursor(c)
+	press(m, key(tea.KeyCtrlC), runes("t"))
+	assert.Contains(t, m.statusLine, "Item has no tagged children")
+}
+
+// press feeds the keys to the model one by one and returns the model
+// which is active afterwards.
+func press(model tea.Model, keys ...tea.KeyMsg) tea.Model {
+	for _, k := range keys {
+		model, _ = model.Update(k)
+	}
+
+	return model
+}
+
+func key(t tea.KeyType) tea.KeyMsg {
+	return tea.KeyMsg{Type: t}
+}
+
+func runes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
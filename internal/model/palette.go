@@ -0,0 +1,146 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+// paletteMode lists the items of the whole tree matching the typed
+// query, best matches first, and passes the selected one to choose.
+// The "go to" palette moves the cursor to it.
+type paletteMode struct {
+	*Outline
+
+	query    textinput.Model
+	matches  []*data.Item
+	selected int
+
+	choose func(m *Outline, item *data.Item) (tea.Model, tea.Cmd)
+}
+
+func (m *Outline) openPalette() (tea.Model, tea.Cmd) {
+	return m.openPaletteWith("go to: ", (*Outline).reveal)
+}
+
+// openPaletteWith opens the palette with the prompt and the function
+// called on the selected item.
+func (m *Outline) openPaletteWith(prompt string, choose func(m *Outline, item *data.Item) (tea.Model, tea.Cmd)) (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+	m.statusLine = ""
+
+	p := paletteMode{Outline: m, choose: choose}
+	p.query = textinput.New()
+	p.query.Prompt = prompt
+	p.query.Focus()
+	p.matches = m.workspace.FuzzyFind("")
+
+	return p, nil
+}
+
+// itemPath returns the titles of the item ancestors, starting from the
+// real root, joined the same way as the breadcrumbs.
+func itemPath(item *data.Item) string {
+	var path string
+	for p := item.Parent(); p != nil; p = p.Parent() {
+		path = p.Title() + " / " + path
+	}
+
+	return path
+}
+
+// goTo zooms into the item parent and places the cursor on the item.
+func (m *Outline) goTo(item *data.Item) (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+	m.workspace.SetRoot(item.Parent())
+
+	return m.moveCursor(item)
+}
+
+func (m paletteMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m.fileChanged(m)
+	case tea.WindowSizeMsg:
+		m.updateWindowSize(msg)
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEsc:
+			return m.Outline, nil
+		case tea.KeyEnter:
+			if len(m.matches) == 0 {
+				return m, nil
+			}
+			return m.choose(m.Outline, m.matches[m.selected])
+		case tea.KeyUp:
+			m.selected = max(m.selected-1, 0)
+			return m, nil
+		case tea.KeyDown:
+			m.selected = max(min(m.selected+1, len(m.matches)-1), 0)
+			return m, nil
+		}
+
+		var cmd tea.Cmd
+		m.query, cmd = m.query.Update(msg)
+		m.matches = m.workspace.FuzzyFind(m.query.Value())
+		m.selected = 0
+
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m paletteMode) View() string {
+	if m.windowWidth == 0 || m.windowHeight == 0 {
+		return ""
+	}
+
+	rows := []string{m.query.View()}
+
+	listHeight := m.windowHeight - 1
+	offset := clampOffset(m.selected-listHeight+1, len(m.matches), listHeight)
+	for idx := offset; idx < len(m.matches) && idx < offset+listHeight; idx++ {
+		item := m.matches[idx]
+
+		path := itemPath(item)
+
+		row := runewidth.Truncate(path+item.Title(), m.windowWidth, "...")
+		if idx == m.selected {
+			row = m.styles.paletteSelected.Render(row)
+		} else if strings.HasPrefix(row, path) {
+			row = m.styles.palettePath.Render(path) + row[len(path):]
+		}
+
+		rows = append(rows, row)
+	}
+
+	return lipgloss.Place(
+		m.windowWidth,
+		m.windowHeight,
+		lipgloss.Left,
+		lipgloss.Top,
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+	)
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestPalette(t *testing.T) {
+	t.Run("Ranking", func(t *testing.T) {
+		m, a, b, _ := newTestOutline(t)
+		nested := m.workspace.NewItem("Buy milk")
+		a.Append(nested)
+		b.SetTitle("Submarine")
+
+		model := press(m, key(tea.KeyCtrlX), runes("g"), runes("bm"))
+		p, ok := model.(paletteMode)
+		require.True(t, ok)
+
+		// the matches at the word starts rank higher, even deeper in
+		// the tree
+		assert.Equal(t, []*data.Item{nested, b}, p.matches)
+		assert.Contains(t, ansi.Strip(p.View()), "Root / ChildA / Buy milk")
+
+		model = press(model, runes("x"))
+		assert.Empty(t, model.(paletteMode).matches)
+	})
+
+	t.Run("Select", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+		nested := m.workspace.NewItem("Nested")
+		a.Append(nested)
+		a.SetCollapsed(true, false)
+		root := m.workspace.Root()
+
+		model := press(m, key(tea.KeyCtrlX), runes("g"), runes("nest"), key(tea.KeyEnter))
+		assert.Same(t, m, model)
+		assert.Same(t, nested, m.workspace.Cursor())
+		assert.False(t, a.Collapsed())
+		assert.Same(t, root, m.workspace.Root())
+	})
+
+	t.Run("OutsideOfRoot", func(t *testing.T) {
+		m, a, b, _ := newTestOutline(t)
+		nested := m.workspace.NewItem("Nested")
+		a.Append(nested)
+		m.workspace.SetRoot(b)
+
+		press(m, key(tea.KeyCtrlX), runes("g"), runes("nest"), key(tea.KeyEnter))
+		assert.Same(t, nested, m.workspace.Cursor())
+		assert.Same(t, m.workspace.Root().RealRoot(), m.workspace.Root())
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+
+		model := press(m, key(tea.KeyCtrlX), runes("g"), runes("childc"), key(tea.KeyEsc))
+		assert.Same(t, m, model)
+		assert.Same(t, a, m.workspace.Cursor())
+	})
+}
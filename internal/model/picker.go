@@ -0,0 +1,138 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// pickerMode lists the names containing the typed query and passes the
+// selected one to choose. If newHint is set, a query matching no name
+// is chosen as a new name.
+type pickerMode struct {
+	*Outline
+
+	query    textinput.Model
+	names    []string
+	matches  []string
+	selected int
+	newHint  string
+
+	choose func(m *Outline, name string) (tea.Model, tea.Cmd)
+}
+
+// openPicker opens the picker of the names with the prompt and the
+// function called on the chosen name.
+func (m *Outline) openPicker(prompt string, names []string, choose func(m *Outline, name string) (tea.Model, tea.Cmd)) pickerMode {
+	m.saveCurrentTitle()
+	m.statusLine = ""
+
+	p := pickerMode{Outline: m, names: names, matches: names, choose: choose}
+	p.query = textinput.New()
+	p.query.Prompt = prompt
+	p.query.Focus()
+
+	return p
+}
+
+// filterNames returns the names containing the query, ignoring the
+// case.
+func filterNames(names []string, query string) []string {
+	query = strings.ToLower(query)
+
+	var matches []string
+	for _, n := range names {
+		if strings.Contains(strings.ToLower(n), query) {
+			matches = append(matches, n)
+		}
+	}
+
+	return matches
+}
+
+func (m pickerMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m.fileChanged(m)
+	case tea.WindowSizeMsg:
+		m.updateWindowSize(msg)
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEsc:
+			return m.Outline, nil
+		case tea.KeyEnter:
+			if len(m.matches) > 0 {
+				return m.choose(m.Outline, m.matches[m.selected])
+			}
+			if name := strings.TrimSpace(m.query.Value()); name != "" && m.newHint != "" {
+				return m.choose(m.Outline, name)
+			}
+			return m, nil
+		case tea.KeyUp:
+			m.selected = max(m.selected-1, 0)
+			return m, nil
+		case tea.KeyDown:
+			m.selected = max(min(m.selected+1, len(m.matches)-1), 0)
+			return m, nil
+		}
+
+		var cmd tea.Cmd
+		m.query, cmd = m.query.Update(msg)
+		m.matches = filterNames(m.names, m.query.Value())
+		m.selected = 0
+
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m pickerMode) View() string {
+	if m.windowWidth == 0 || m.windowHeight == 0 {
+		return ""
+	}
+
+	rows := []string{m.query.View()}
+
+	listHeight := m.windowHeight - 1
+	offset := clampOffset(m.selected-listHeight+1, len(m.matches), listHeight)
+	for idx := offset; idx < len(m.matches) && idx < offset+listHeight; idx++ {
+		row := runewidth.Truncate(m.matches[idx], m.windowWidth, "...")
+		if idx == m.selected {
+			row = m.styles.paletteSelected.Render(row)
+		}
+
+		rows = append(rows, row)
+	}
+
+	if len(m.matches) == 0 && m.newHint != "" && strings.TrimSpace(m.query.Value()) != "" {
+		rows = append(rows, m.styles.palettePath.Render("[enter] "+m.newHint))
+	}
+
+	return lipgloss.Place(
+		m.windowWidth,
+		m.windowHeight,
+		lipgloss.Left,
+		lipgloss.Top,
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+	)
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	progressBarWidth = 6
+
+	progressFull  = "█" // U+2588
+	progressEmpty = "░" // U+2591
+)
+
+// progressPercent returns the completed share of the total in percent,
+// rounded to the nearest integer, or 0 if the total is 0.
+func progressPercent(completed, total int) int {
+	if total <= 0 {
+		return 0
+	}
+
+	return (min(max(completed, 0), total)*100 + total/2) / total
+}
+
+// renderProgressBar returns the progress bar of the completed share of
+// the total followed by the percentage, or an empty string if the total
+// is 0.
+func renderProgressBar(completed, total int) string {
+	if total <= 0 {
+		return ""
+	}
+
+	percent := progressPercent(completed, total)
+	full := percent * progressBarWidth / 100
+
+	return fmt.Sprintf("[%s%s] %d%%",
+		strings.Repeat(progressFull, full),
+		strings.Repeat(progressEmpty, progressBarWidth-full),
+		percent,
+	)
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestProgressPercent(t *testing.T) {
+	assert.Equal(t, 0, progressPercent(0, 0))
+	assert.Equal(t, 0, progressPercent(0, 3))
+	assert.Equal(t, 33, progressPercent(1, 3))
+	assert.Equal(t, 67, progressPercent(2, 3))
+	assert.Equal(t, 100, progressPercent(3, 3))
+	assert.Equal(t, 100, progressPercent(5, 3))
+}
+
+func TestProgressBar(t *testing.T) {
+	assert.Empty(t, renderProgressBar(0, 0))
+	assert.Equal(t, "[░░░░░░] 0%", renderProgressBar(0, 2))
+	assert.Equal(t, "[███░░░] 50%", renderProgressBar(1, 2))
+	assert.Equal(t, "[████░░] 67%", renderProgressBar(2, 3))
+	assert.Equal(t, "[██████] 100%", renderProgressBar(2, 2))
+
+	m, a, b, c := newTestOutline(t)
+	a.Append(b)
+	a.Append(c)
+	b.SetStatus(data.StatusDone)
+	c.SetStatus(data.StatusToDo)
+
+	assert.NotContains(t, ansi.Strip(m.renderItemEntry(a)), "50%")
+
+	m.config.ProgressBars = true
+	assert.Contains(t, ansi.Strip(m.renderItemEntry(a)), "(1/2) [███░░░] 50%")
+
+	// the items without the statused children have no bar
+	assert.NotContains(t, ansi.Strip(m.renderItemEntry(b)), "[")
+}
+
+func TestDeepToDoStats(t *testing.T) {
+	m, a, b, c := newTestOutline(t)
+	a.Append(b)
+	b.Append(c)
+	b.SetStatus(data.StatusToDo)
+	c.SetStatus(data.StatusDone)
+
+	assert.Contains(t, ansi.Strip(m.renderItemEntry(a)), "(0/1)")
+
+	m.config.DeepToDoStats = true
+	assert.Contains(t, ansi.Strip(m.renderItemEntry(a)), "(1/2)")
+}
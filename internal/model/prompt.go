@@ -0,0 +1,69 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// promptMode reads a single line value in the status line and passes
+// it to the submit function on Enter. Esc cancels the prompt.
+type promptMode struct {
+	*Outline
+
+	input  textinput.Model
+	submit func(m *Outline, value string) (tea.Model, tea.Cmd)
+}
+
+func (m *Outline) prompt(prompt string, submit func(m *Outline, value string) (tea.Model, tea.Cmd)) (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+	m.statusLine = ""
+
+	p := promptMode{Outline: m, submit: submit}
+	p.input = textinput.New()
+	p.input.Prompt = prompt
+	p.input.Focus()
+
+	return p, nil
+}
+
+func (m promptMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m.fileChanged(m)
+	case tea.WindowSizeMsg:
+		m.updateWindowSize(msg)
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEsc:
+			return m.finishEdit(m.Outline, nil)
+		case tea.KeyEnter:
+			return m.finishEdit(m.submit(m.Outline, m.input.Value()))
+		}
+
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m promptMode) View() string {
+	return m.renderView(m.input.View())
+}
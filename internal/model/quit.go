@@ -0,0 +1,72 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// quit exits the program, unless there are unsaved changes, which have
+// to be confirmed first.
+func (m *Outline) quit() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+
+	if !m.workspace.Dirty() {
+		m.statusLine = ""
+		return m, tea.Quit
+	}
+
+	m.statusLine = m.keys.quitConfirm.menu() + "  [esc] cancel"
+	return quitConfirmMode{m}, nil
+}
+
+// quitConfirmMode asks what to do with the unsaved changes on quit.
+type quitConfirmMode struct {
+	*Outline
+}
+
+func (m quitConfirmMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m.fileChanged(m)
+	case tea.WindowSizeMsg:
+		m.updateWindowSize(msg)
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyEsc {
+			m.Outline.statusLine = ""
+			return m.Outline, nil
+		}
+
+		switch m.keys.quitConfirm.match(msg) {
+		case "quitAnyway":
+			m.Outline.statusLine = ""
+			return m.Outline, tea.Quit
+		case "saveAndQuit":
+			if err := m.workspace.Save(); err != nil {
+				m.Outline.statusLine = m.renderStatusError(err.Error())
+				return m.Outline, nil
+			}
+
+			m.Outline.statusLine = ""
+			return m.Outline, tea.Quit
+		default:
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
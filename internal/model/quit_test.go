@@ -0,0 +1,82 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// isQuit reports whether the command quits the program.
+func isQuit(cmd tea.Cmd) bool {
+	if cmd == nil {
+		return false
+	}
+
+	_, ok := cmd().(tea.QuitMsg)
+	return ok
+}
+
+func TestQuit(t *testing.T) {
+	t.Run("NoChanges", func(t *testing.T) {
+		m, _, _, _ := newTestOutline(t)
+		require.NoError(t, m.workspace.Save())
+
+		mode := press(m, key(tea.KeyCtrlX))
+		_, cmd := mode.Update(runes("q"))
+		assert.True(t, isQuit(cmd))
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+		a.SetTitle("Changed")
+
+		mode := press(m, key(tea.KeyCtrlX), runes("q"))
+		assert.IsType(t, quitConfirmMode{}, mode)
+		assert.Contains(t, ansi.Strip(m.View()), "unsaved changes: [q] quit anyway  [s] save and quit  [esc] cancel")
+
+		mode, cmd := mode.Update(key(tea.KeyEsc))
+		assert.Same(t, m, mode)
+		assert.False(t, isQuit(cmd))
+		assert.True(t, m.workspace.Dirty())
+	})
+
+	t.Run("QuitAnyway", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+		a.SetTitle("Changed")
+
+		mode := press(m, key(tea.KeyCtrlX), runes("q"))
+		_, cmd := mode.Update(runes("q"))
+		assert.True(t, isQuit(cmd))
+		assert.True(t, m.workspace.Dirty())
+	})
+
+	t.Run("SaveAndQuit", func(t *testing.T) {
+		m, _, _, _ := newTestOutline(t)
+		require.NoError(t, m.workspace.Save())
+
+		// the title being edited counts as a change
+		press(m, runes("!"))
+		mode := press(m, key(tea.KeyCtrlX), runes("q"))
+		_, cmd := mode.Update(runes("s"))
+		assert.True(t, isQuit(cmd))
+		assert.False(t, m.workspace.Dirty())
+		assert.Equal(t, "ChildA!", m.workspace.Cursor().Title())
+	})
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+// clampOffset returns the scroll offset limited to the range which
+// keeps a window of the given height filled with lines, if possible.
+func clampOffset(offset, total, height int) int {
+	maxOffset := max(total-height, 0)
+	return min(max(offset, 0), maxOffset)
+}
+
+// wrapText wraps every line of the text to the given width, keeping
+// the leading indentation on the continuation lines.
+func wrapText(text string, width int) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		body := strings.TrimLeft(line, " ")
+		indent := line[:len(line)-len(body)]
+
+		bodyWidth := width - len(indent)
+		if bodyWidth < 1 {
+			lines = append(lines, line)
+			continue
+		}
+
+		wrapped := lipgloss.NewStyle().Width(bodyWidth).Render(body)
+		for _, l := range strings.Split(wrapped, "\n") {
+			lines = append(lines, indent+strings.TrimRight(l, " "))
+		}
+	}
+
+	return lines
+}
+
+// readerMode shows a read-only text scrolled by the arrow keys. Any
+// other key returns to the outline.
+type readerMode struct {
+	*Outline
+
+	text   string
+	lines  []string
+	offset int
+}
+
+func newReaderMode(m *Outline, text string) readerMode {
+	r := readerMode{Outline: m, text: text}
+	r.lines = wrapText(text, m.windowWidth)
+	return r
+}
+
+func (m *Outline) openReader() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+
+	var buf bytes.Buffer
+	if err := data.ExportText(m.workspace.Root(), &buf); err != nil {
+		m.statusLine = m.renderStatusError(err.Error())
+		return m, nil
+	}
+
+	m.statusLine = ""
+	return newReaderMode(m, buf.String()), nil
+}
+
+func (m *Outline) openXMLView() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+
+	out, err := m.workspace.Cursor().MarshalXMLBytes()
+	if err != nil {
+		m.statusLine = m.renderStatusError(err.Error())
+		return m, nil
+	}
+
+	m.statusLine = ""
+	return newReaderMode(m, string(out)), nil
+}
+
+// openStats shows the statistics of the whole tree.
+func (m *Outline) openStats() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+	m.statusLine = ""
+
+	s := m.workspace.Stats()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Items: %d\n", s.Items)
+	fmt.Fprintf(&b, "Maximum depth: %d\n", s.MaxDepth)
+	fmt.Fprintf(&b, "Collapsed branches: %d\n", s.Collapsed)
+	b.WriteString("\n")
+	for st := data.StatusNone; st <= data.StatusScheduled; st++ {
+		fmt.Fprintf(&b, "%s: %d\n", st, s.Statuses[st])
+	}
+
+	return newReaderMode(m, b.String()), nil
+}
+
+func (m readerMode) scroll(delta int) readerMode {
+	m.offset = clampOffset(m.offset+delta, len(m.lines), m.windowHeight)
+	return m
+}
+
+func (m readerMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m.fileChanged(m)
+	case tea.WindowSizeMsg:
+		m.updateWindowSize(msg)
+		m.lines = wrapText(m.text, m.windowWidth)
+		return m.scroll(0), nil
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyUp:
+			return m.scroll(-1), nil
+		case tea.KeyDown:
+			return m.scroll(1), nil
+		case tea.KeyPgUp:
+			return m.scroll(-m.windowHeight), nil
+		case tea.KeyPgDown:
+			return m.scroll(m.windowHeight), nil
+		case tea.KeyHome:
+			return m.scroll(-len(m.lines)), nil
+		case tea.KeyEnd:
+			return m.scroll(len(m.lines)), nil
+		default:
+			return m.Outline, nil
+		}
+	}
+
+	return m, nil
+}
+
+func (m readerMode) View() string {
+	end := min(m.offset+m.windowHeight, len(m.lines))
+	content := strings.Join(m.lines[m.offset:end], "\n")
+
+	return lipgloss.Place(m.windowWidth, m.windowHeight, lipgloss.Left, lipgloss.Top, content)
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestClampOffset(t *testing.T) {
+	t.Run("ShorterThanWindow", func(t *testing.T) {
+		assert.Equal(t, 0, clampOffset(0, 5, 10))
+		assert.Equal(t, 0, clampOffset(3, 5, 10))
+		assert.Equal(t, 0, clampOffset(-1, 5, 10))
+	})
+
+	t.Run("LongerThanWindow", func(t *testing.T) {
+		assert.Equal(t, 0, clampOffset(-3, 25, 10))
+		assert.Equal(t, 7, clampOffset(7, 25, 10))
+		assert.Equal(t, 15, clampOffset(15, 25, 10))
+		assert.Equal(t, 15, clampOffset(40, 25, 10))
+	})
+}
+
+func TestWrapText(t *testing.T) {
+	lines := wrapText("short\n  indented long line\n", 12)
+
+	assert.Equal(t, []string{
+		"short",
+		"  indented",
+		"  long line",
+	}, lines)
+}
+
+func TestReaderModeScroll(t *testing.T) {
+	m := &Outline{windowWidth: 20, windowHeight: 3}
+	r := newReaderMode(m, "a\nb\nc\nd\ne\n")
+
+	r = r.scroll(1)
+	assert.Equal(t, 1, r.offset)
+
+	r = r.scroll(10)
+	assert.Equal(t, 2, r.offset)
+
+	r = r.scroll(-10)
+	assert.Equal(t, 0, r.offset)
+}
+
+func TestStatsView(t *testing.T) {
+	m, a, b, _ := newTestOutline(t)
+	a.Append(b)
+	a.SetCollapsed(true, false)
+	b.SetStatus(data.StatusToDo)
+
+	model := press(m, key(tea.KeyCtrlX), runes("t"))
+	require.IsType(t, readerMode{}, model)
+
+	view := model.View()
+	assert.Contains(t, view, "Items: 3")
+	assert.Contains(t, view, "Maximum depth: 2")
+	assert.Contains(t, view, "Collapsed branches: 1")
+	assert.Contains(t, view, "NONE: 2")
+	assert.Contains(t, view, "TODO: 1")
+	assert.Contains(t, view, "SCHD: 0")
+}
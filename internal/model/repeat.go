@@ -0,0 +1,91 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+// action is a mutating outline command which can be replayed on the
+// current cursor. Parametrized commands capture their arguments.
+type action func(m *Outline) (tea.Model, tea.Cmd)
+
+func deleteItemAction(recursive bool) action {
+	return func(m *Outline) (tea.Model, tea.Cmd) {
+		return m.deleteItem(recursive)
+	}
+}
+
+func sortChildrenAction(recursive bool) action {
+	return func(m *Outline) (tea.Model, tea.Cmd) {
+		return m.sortChildren(recursive)
+	}
+}
+
+func archiveCompletedAction(recursive bool) action {
+	return func(m *Outline) (tea.Model, tea.Cmd) {
+		return m.archiveCompleted(recursive)
+	}
+}
+
+func setStatusAction(s data.Status) action {
+	return func(m *Outline) (tea.Model, tea.Cmd) {
+		return m.setStatus(s)
+	}
+}
+
+func setStatusRecursiveAction(s data.Status) action {
+	return func(m *Outline) (tea.Model, tea.Cmd) {
+		return m.setStatusRecursive(s)
+	}
+}
+
+func cycleStatusAction(forward bool) action {
+	return func(m *Outline) (tea.Model, tea.Cmd) {
+		return m.cycleStatus(forward)
+	}
+}
+
+func setPriorityAction(p data.Priority) action {
+	return func(m *Outline) (tea.Model, tea.Cmd) {
+		return m.setPriority(p)
+	}
+}
+
+func scheduleAction(due time.Time) action {
+	return func(m *Outline) (tea.Model, tea.Cmd) {
+		return m.schedule(due)
+	}
+}
+
+// do performs the action as a single undo step and remembers it for
+// repeating.
+func (m *Outline) do(a action) (tea.Model, tea.Cmd) {
+	m.lastAction = a
+	return m.undoable(a)
+}
+
+// repeatLastAction replays the last performed action on the cursor.
+func (m *Outline) repeatLastAction() (tea.Model, tea.Cmd) {
+	if m.lastAction == nil {
+		return m, nil
+	}
+
+	return m.undoable(m.lastAction)
+}
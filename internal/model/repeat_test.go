@@ -0,0 +1,68 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestRepeatLastAction(t *testing.T) {
+	t.Run("NothingToRepeat", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+
+		press(m, key(tea.KeyCtrlR))
+
+		assert.Same(t, a, m.workspace.Cursor())
+		assert.Equal(t, data.StatusNone, a.Status())
+	})
+
+	t.Run("SetStatus", func(t *testing.T) {
+		m, a, b, _ := newTestOutline(t)
+
+		press(m, key(tea.KeyCtrlC), runes("s"), runes("w"))
+		assert.Equal(t, data.StatusWaiting, a.Status())
+
+		press(m, key(tea.KeyCtrlDown), key(tea.KeyCtrlR))
+		assert.Same(t, b, m.workspace.Cursor())
+		assert.Equal(t, data.StatusWaiting, b.Status())
+	})
+
+	t.Run("Demote", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+
+		press(m, key(tea.KeyCtrlDown), key(tea.KeyCtrlShiftRight))
+		assert.Same(t, a, b.Parent())
+
+		press(m, key(tea.KeyCtrlDown), key(tea.KeyCtrlR))
+		assert.Same(t, c, m.workspace.Cursor())
+		assert.Same(t, a, c.Parent())
+	})
+
+	t.Run("NavigationIsNotRecorded", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+
+		press(m, key(tea.KeyCtrlC), runes("d"))
+		assert.Equal(t, []*data.Item{a}, m.workspace.Trashed())
+
+		press(m, key(tea.KeyCtrlDown), key(tea.KeyCtrlUp), key(tea.KeyCtrlR))
+		assert.Equal(t, []*data.Item{b, a}, m.workspace.Trashed())
+		assert.Same(t, c, m.workspace.Cursor())
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+// capture holds the view state to return to after a quick capture.
+type capture struct {
+	root   *data.Item
+	cursor *data.Item
+
+	// item created for the captured text
+	item *data.Item
+}
+
+// openScratch zooms into the inbox item set by the config, creating it
+// if absent, and places the cursor on a new empty item there.
+func (m *Outline) openScratch() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+
+	m.workspace.BeginBatch()
+	defer m.workspace.EndBatch()
+
+	if m.capture == nil {
+		m.capture = &capture{
+			root:   m.workspace.Root(),
+			cursor: m.workspace.Cursor(),
+		}
+	} else if m.capture.item.Title() == "" {
+		m.capture.item.Detach()
+	}
+
+	scratch := m.workspace.Inbox(m.config.Inbox)
+
+	item := m.workspace.NewItem("")
+	scratch.Append(item)
+	m.capture.item = item
+
+	m.workspace.SetRoot(scratch)
+
+	return m.moveCursor(item)
+}
+
+// returnFromCapture restores the view state saved by a quick capture.
+// The captured item is dropped if it was left empty.
+func (m *Outline) returnFromCapture() (tea.Model, tea.Cmd) {
+	c := m.capture
+	m.capture = nil
+
+	m.saveCurrentTitle()
+
+	m.workspace.BeginBatch()
+	defer m.workspace.EndBatch()
+	if c.item.Title() == "" {
+		c.item.Detach()
+	}
+
+	m.workspace.SetRoot(c.root)
+
+	// the item might have been deleted while capturing
+	cursor := c.cursor
+	if cursor.Depth() < 1 {
+		cursor = c.root.Head()
+	}
+
+	if cursor == nil {
+		cursor = m.workspace.NewItem("")
+		c.root.Append(cursor)
+	}
+
+	return m.moveCursor(cursor)
+}
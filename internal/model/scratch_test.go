@@ -0,0 +1,70 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScratch(t *testing.T) {
+	t.Run("CreatesScratchOnce", func(t *testing.T) {
+		m, a, _, c := newTestOutline(t)
+		root := m.workspace.Root()
+
+		press(m, key(tea.KeyCtrlN), runes("one"), key(tea.KeyEsc))
+		press(m, key(tea.KeyCtrlN), runes("two"), key(tea.KeyEsc))
+
+		scratch := c.Next()
+		require.NotNil(t, scratch)
+		assert.Equal(t, m.config.Inbox, scratch.Title())
+		assert.Nil(t, scratch.Next())
+
+		require.NotNil(t, scratch.Head())
+		assert.Equal(t, "one", scratch.Head().Title())
+		assert.Equal(t, "two", scratch.Tail().Title())
+
+		assert.Same(t, root, m.workspace.Root())
+		assert.Same(t, a, m.workspace.Cursor())
+	})
+
+	t.Run("RestoresZoom", func(t *testing.T) {
+		m, a, b, _ := newTestOutline(t)
+
+		a.Append(b)
+		m.workspace.SetRoot(a)
+		m.workspace.SetCursor(b)
+
+		press(m, key(tea.KeyCtrlN))
+		assert.Equal(t, m.config.Inbox, m.workspace.Root().Title())
+
+		press(m, key(tea.KeyEsc))
+		assert.Same(t, a, m.workspace.Root())
+		assert.Same(t, b, m.workspace.Cursor())
+	})
+
+	t.Run("DropsEmptyCapture", func(t *testing.T) {
+		m, _, _, c := newTestOutline(t)
+
+		press(m, key(tea.KeyCtrlN), key(tea.KeyEsc))
+
+		scratch := c.Next()
+		require.NotNil(t, scratch)
+		assert.Nil(t, scratch.Head())
+	})
+}
@@ -0,0 +1,302 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+type search struct {
+	query string
+
+	// matching items in the document order
+	matches []*data.Item
+	current int
+
+	// whether the matches are highlighted, while the search is on
+	active bool
+}
+
+// reveal places the cursor on the item, zooming out to the real root
+// if the item is outside of the view root and expanding its collapsed
+// ancestors.
+func (m *Outline) reveal(item *data.Item) (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+
+	if item.Depth() < 1 {
+		m.workspace.SetRoot(item.RealRoot())
+	}
+
+	root := m.workspace.Root()
+	for p := item.Parent(); p != nil && p != root; p = p.Parent() {
+		p.SetCollapsed(false, false)
+	}
+
+	return m.moveCursor(item)
+}
+
+// searchMode reads the search query, jumping to the first match as it
+// is typed. After Enter, the matches are cycled through until any other
+// key, which is then handled by the outline.
+type searchMode struct {
+	*Outline
+
+	input    textinput.Model
+	browsing bool
+
+	// view root at the start of the search, the subtree searched in if
+	// the search is limited to it
+	zoomRoot *data.Item
+}
+
+func (m *Outline) startSearch() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+	m.statusLine = ""
+	m.search = search{active: true}
+
+	s := searchMode{Outline: m, zoomRoot: m.workspace.Root()}
+	s.input = textinput.New()
+	s.input.Prompt = s.prompt()
+	s.input.Focus()
+
+	return s, nil
+}
+
+// prompt returns the search input prompt naming the search scope.
+func (m searchMode) prompt() string {
+	if m.searchSubtree {
+		return "search (this subtree): "
+	}
+
+	return "search (whole document): "
+}
+
+// searchRoot returns the item whose descendants are searched.
+func (m searchMode) searchRoot() *data.Item {
+	if m.searchSubtree {
+		return m.zoomRoot
+	}
+
+	return m.zoomRoot.RealRoot()
+}
+
+// toggleScope switches the search between the subtree zoomed into at
+// its start and the whole document, and searches again. Limiting the
+// search zooms back into the subtree.
+func (m searchMode) toggleScope() (tea.Model, tea.Cmd) {
+	m.searchSubtree = !m.searchSubtree
+	m.input.Prompt = m.prompt()
+
+	if m.searchSubtree {
+		m.workspace.SetRoot(m.zoomRoot)
+	}
+	m.updateSearch(m.search.query, m.searchRoot())
+
+	return m, nil
+}
+
+// updateSearch collects the matches of the query among the descendants
+// of the root and reveals the first one.
+func (m *Outline) updateSearch(query string, root *data.Item) {
+	m.search = search{query: query, active: true}
+	if query == "" {
+		m.statusLine = ""
+		return
+	}
+
+	m.search.matches = root.Search(query)
+	m.jumpToMatch(0)
+}
+
+// cycleMatch reveals the match delta positions away from the current
+// one, wrapping around at the ends.
+func (m *Outline) cycleMatch(delta int) (tea.Model, tea.Cmd) {
+	n := len(m.search.matches)
+	if n == 0 {
+		return m.jumpToMatch(0)
+	}
+
+	return m.jumpToMatch(((m.search.current+delta)%n + n) % n)
+}
+
+func (m searchMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m.fileChanged(m)
+	case tea.WindowSizeMsg:
+		m.updateWindowSize(msg)
+	case tea.KeyMsg:
+		if m.browsing {
+			return m.browse(msg)
+		}
+
+		if m.keys.search.match(msg) == "toggleScope" {
+			return m.toggleScope()
+		}
+
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.search.active = false
+			m.Outline.statusLine = ""
+			return m.Outline, nil
+		case tea.KeyEnter:
+			if len(m.search.matches) == 0 {
+				// keep the "No matches" error
+				m.search.active = false
+				return m.Outline, nil
+			}
+
+			m.browsing = true
+			return m, nil
+		}
+
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		if m.input.Value() != m.search.query {
+			m.updateSearch(m.input.Value(), m.searchRoot())
+		}
+
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+// browse cycles through the matches. Any other key ends the search,
+// leaving the cursor on the current match.
+func (m searchMode) browse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.search.match(msg) {
+	case "nextMatch":
+		m.cycleMatch(1)
+		return m, nil
+	case "prevMatch":
+		m.cycleMatch(-1)
+		return m, nil
+	case "toggleScope":
+		return m.toggleScope()
+	}
+
+	m.search.active = false
+	m.Outline.statusLine = ""
+
+	if msg.Type == tea.KeyEsc || msg.Type == tea.KeyEnter {
+		return m.Outline, nil
+	}
+
+	return m.Outline.Update(msg)
+}
+
+func (m searchMode) View() string {
+	if m.browsing {
+		return m.renderView(m.Outline.statusLine + " " + m.keys.search.menu())
+	}
+
+	return m.renderView(m.input.View() + " " + m.Outline.statusLine)
+}
+
+// renderTitleLine renders a line of the item title with the matches of
+// the active search highlighted.
+func (m *Outline) renderTitleLine(line string, style lipgloss.Style) string {
+	if !m.search.active || m.search.query == "" {
+		return style.Render(line)
+	}
+
+	var b strings.Builder
+	for _, part := range splitMatches(line, m.search.query) {
+		if part.match {
+			b.WriteString(m.styles.searchMatch.Inherit(style).Render(part.text))
+		} else if part.text != "" {
+			b.WriteString(style.Render(part.text))
+		}
+	}
+
+	return b.String()
+}
+
+type matchPart struct {
+	text  string
+	match bool
+}
+
+// splitMatches splits the string into the parts matching the query,
+// ignoring case, and the ones between them.
+func splitMatches(s, query string) []matchPart {
+	q := []rune(query)
+	r := []rune(s)
+	if len(q) == 0 {
+		return []matchPart{{s, false}}
+	}
+
+	var parts []matchPart
+	start := 0
+	for i := 0; i+len(q) <= len(r); {
+		if !strings.EqualFold(string(r[i:i+len(q)]), query) {
+			i++
+			continue
+		}
+
+		parts = append(parts,
+			matchPart{string(r[start:i]), false},
+			matchPart{string(r[i : i+len(q)]), true},
+		)
+		i += len(q)
+		start = i
+	}
+
+	return append(parts, matchPart{string(r[start:]), false})
+}
+
+// jumpToMatch reveals the search match with the given index, clamped
+// to the range of the matches.
+func (m *Outline) jumpToMatch(idx int) (tea.Model, tea.Cmd) {
+	matches := m.search.matches
+	if len(matches) == 0 {
+		m.statusLine = m.renderStatusError("No matches")
+		return m, nil
+	}
+
+	idx = min(max(idx, 0), len(matches)-1)
+	m.search.current = idx
+
+	m.statusLine = m.renderStatusMessage(fmt.Sprintf("Match %d of %d", idx+1, len(matches)))
+	return m.reveal(matches[idx])
+}
+
+func (m *Outline) promptMatchNumber() (tea.Model, tea.Cmd) {
+	if len(m.search.matches) == 0 {
+		m.statusLine = m.renderStatusError("No search results")
+		return m, nil
+	}
+
+	return m.prompt("go to match #: ", func(m *Outline, value string) (tea.Model, tea.Cmd) {
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			m.statusLine = m.renderStatusError("Not a number: " + value)
+			return m, nil
+		}
+
+		return m.jumpToMatch(n - 1)
+	})
+}
@@ -0,0 +1,217 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestSearch(t *testing.T) {
+	t.Run("CollectsAllMatches", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+
+		press(m, key(tea.KeyCtrlX), runes("/"), runes("child"), key(tea.KeyEnter))
+
+		assert.Equal(t, []*data.Item{a, b, c}, m.search.matches)
+		assert.Same(t, a, m.workspace.Cursor())
+	})
+
+	t.Run("JumpToMatch", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+
+		// hide the last match in a collapsed item outside of the zoom
+		b.Append(c)
+		b.SetCollapsed(true, false)
+		a.Append(m.workspace.NewItem("Zoomed"))
+		m.workspace.SetRoot(a)
+		m.moveCursor(a.Head())
+
+		press(m, key(tea.KeyCtrlX), runes("/"), runes("child"), key(tea.KeyEnter))
+		press(m, key(tea.KeyCtrlX), runes("#"), runes("3"), key(tea.KeyEnter))
+
+		assert.Equal(t, 2, m.search.current)
+		assert.Same(t, c, m.workspace.Cursor())
+		assert.Same(t, m.workspace.Root(), a.Parent())
+		assert.False(t, b.Collapsed())
+	})
+
+	t.Run("ClampsIndex", func(t *testing.T) {
+		m, a, _, c := newTestOutline(t)
+
+		press(m, key(tea.KeyCtrlX), runes("/"), runes("child"), key(tea.KeyEnter))
+
+		m.jumpToMatch(10)
+		assert.Same(t, c, m.workspace.Cursor())
+
+		m.jumpToMatch(-1)
+		assert.Same(t, a, m.workspace.Cursor())
+	})
+
+	t.Run("NoMatches", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+
+		press(m, key(tea.KeyCtrlX), runes("/"), runes("missing"), key(tea.KeyEnter))
+
+		assert.Empty(t, m.search.matches)
+		assert.Same(t, a, m.workspace.Cursor())
+		assert.Contains(t, m.statusLine, "No matches")
+	})
+}
+
+func TestIncrementalSearch(t *testing.T) {
+	t.Run("JumpsWhileTyping", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+		b.SetTitle("Banana")
+		c.SetTitle("Band")
+
+		model := press(m, key(tea.KeyCtrlX), runes("/"), runes("ban"))
+		assert.Equal(t, []*data.Item{b, c}, m.search.matches)
+		assert.Same(t, b, m.workspace.Cursor())
+		assert.Contains(t, model.View(), "Match 1 of 2")
+
+		model = press(model, runes("d"))
+		assert.Same(t, c, m.workspace.Cursor())
+
+		model = press(model, runes("x"))
+		assert.Empty(t, m.search.matches)
+		assert.Contains(t, model.View(), "No matches")
+
+		// canceling leaves the cursor on the last match
+		model = press(model, key(tea.KeyEsc))
+		assert.Same(t, m, model)
+		assert.Same(t, c, m.workspace.Cursor())
+		assert.False(t, m.search.active)
+		assert.NotSame(t, a, m.workspace.Cursor())
+	})
+
+	t.Run("CycleMatches", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+
+		model := press(m, key(tea.KeyCtrlX), runes("/"), runes("child"), key(tea.KeyEnter))
+		assert.Same(t, a, m.workspace.Cursor())
+		assert.Contains(t, model.View(), "search: ")
+
+		model = press(model, runes("n"), runes("n"))
+		assert.Same(t, c, m.workspace.Cursor())
+
+		// the matches wrap around at the ends
+		model = press(model, runes("n"))
+		assert.Same(t, a, m.workspace.Cursor())
+		model = press(model, runes("N"))
+		assert.Same(t, c, m.workspace.Cursor())
+		model = press(model, runes("N"))
+		assert.Same(t, b, m.workspace.Cursor())
+
+		// any other key ends the search and goes to the outline
+		model = press(model, key(tea.KeyCtrlUp))
+		assert.Same(t, m, model)
+		assert.Same(t, a, m.workspace.Cursor())
+		assert.False(t, m.search.active)
+		assert.Equal(t, "ChildB", b.Title())
+	})
+
+	t.Run("Highlight", func(t *testing.T) {
+		profile := lipgloss.ColorProfile()
+		lipgloss.SetColorProfile(termenv.ANSI)
+		t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+
+		m, _, b, _ := newTestOutline(t)
+		b.SetTitle("Bob's BOBcat")
+
+		press(m, key(tea.KeyCtrlX), runes("/"), runes("child"))
+		assert.Contains(t, m.renderItemEntry(m.workspace.Root().Tail()), m.styles.searchMatch.Render("Child"))
+
+		// the cursor row is the text input
+		m.search.query = "bob"
+		assert.Contains(t, m.renderTitleLine(b.Title(), m.styles.itemNormal), m.styles.searchMatch.Render("BOB"))
+
+		m.search.active = false
+		assert.NotContains(t, m.renderTitleLine(b.Title(), m.styles.itemNormal), m.styles.searchMatch.Render("BOB"))
+	})
+}
+
+func TestSearchScope(t *testing.T) {
+	// a match in the zoomed subtree and one outside of it
+	newScopeOutline := func(t *testing.T) (*Outline, *data.Item, *data.Item) {
+		m, a, b, _ := newTestOutline(t)
+		inside := m.workspace.NewItem("Apple pie")
+		outside := m.workspace.NewItem("Apple juice")
+		a.Append(inside)
+		b.Append(outside)
+		m.workspace.SetRoot(a)
+		m.moveCursor(inside)
+
+		return m, inside, outside
+	}
+
+	t.Run("WholeDocument", func(t *testing.T) {
+		m, inside, outside := newScopeOutline(t)
+
+		model := press(m, key(tea.KeyCtrlX), runes("/"))
+		assert.Contains(t, model.View(), "search (whole document): ")
+
+		press(model, runes("apple"), key(tea.KeyEnter))
+		assert.Equal(t, []*data.Item{inside, outside}, m.search.matches)
+	})
+
+	t.Run("ThisSubtree", func(t *testing.T) {
+		m, inside, outside := newScopeOutline(t)
+		a := inside.Parent()
+
+		model := press(m, key(tea.KeyCtrlX), runes("/"), runes("apple"), key(tea.KeyTab))
+		assert.Contains(t, model.View(), "search (this subtree): ")
+		assert.Equal(t, []*data.Item{inside}, m.search.matches)
+
+		// cycling stays in the subtree
+		model = press(model, key(tea.KeyEnter), runes("n"))
+		assert.Same(t, inside, m.workspace.Cursor())
+		assert.Same(t, a, m.workspace.Root())
+
+		// the whole document search zooms out to the match, and
+		// limiting it again zooms back
+		model = press(model, key(tea.KeyTab), runes("n"))
+		assert.Same(t, outside, m.workspace.Cursor())
+		assert.Same(t, a.RealRoot(), m.workspace.Root())
+
+		press(model, key(tea.KeyTab))
+		assert.Equal(t, []*data.Item{inside}, m.search.matches)
+		assert.Same(t, a, m.workspace.Root())
+
+		// the scope is kept for the next search
+		model = press(m, key(tea.KeyCtrlX), runes("/"))
+		assert.Contains(t, model.View(), "search (this subtree): ")
+	})
+}
+
+func TestSplitMatches(t *testing.T) {
+	assert.Equal(t, []matchPart{
+		{"", false},
+		{"Bob", true},
+		{"'s ", false},
+		{"BOB", true},
+		{"cat", false},
+	}, splitMatches("Bob's BOBcat", "bob"))
+
+	assert.Equal(t, []matchPart{{"Привет", false}}, splitMatches("Привет", "x"))
+	assert.Equal(t, []matchPart{{"При", false}, {"ВЕТ", true}, {"", false}}, splitMatches("ПриВЕТ", "вет"))
+	assert.Equal(t, []matchPart{{"any", false}}, splitMatches("any", ""))
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+// selection is a set of items picked for batch operations.
+type selection map[*data.Item]struct{}
+
+func (m *Outline) toggleSelected() (tea.Model, tea.Cmd) {
+	cur := m.workspace.Cursor()
+
+	if _, ok := m.selection[cur]; ok {
+		delete(m.selection, cur)
+	} else {
+		m.selection[cur] = struct{}{}
+	}
+
+	return m, nil
+}
+
+func (m *Outline) clearSelection() {
+	clear(m.selection)
+}
+
+// selectedItems returns the selected items still in the tree in the
+// document order. With topmost set, the items having a selected
+// ancestor are left out, so that the recursive operations process
+// every item once.
+func (m *Outline) selectedItems(topmost bool) []*data.Item {
+	var items []*data.Item
+
+	var walk func(parent *data.Item)
+	walk = func(parent *data.Item) {
+		for c := parent.Head(); c != nil; c = c.Next() {
+			if _, ok := m.selection[c]; ok {
+				items = append(items, c)
+				if topmost {
+					continue
+				}
+			}
+			walk(c)
+		}
+	}
+	walk(m.workspace.Root().RealRoot())
+
+	return items
+}
+
+// targets returns the items a batch operation applies to: the selected
+// ones if there are any, the cursor item otherwise.
+func (m *Outline) targets(topmost bool) []*data.Item {
+	if items := m.selectedItems(topmost); len(items) > 0 {
+		return items
+	}
+
+	return []*data.Item{m.workspace.Cursor()}
+}
+
+// deleteSelected deletes the selected items along with their
+// descendants and moves the cursor to the nearest remaining row if the
+// cursor item is deleted.
+func (m *Outline) deleteSelected(recursive bool) (tea.Model, tea.Cmd) {
+	items := m.selectedItems(true)
+
+	deleted := func(item *data.Item) bool {
+		for _, d := range items {
+			if item == d || item.IsDescendantOf(d) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !recursive {
+		for _, item := range items {
+			if item.Head() != nil {
+				m.statusLine = m.renderStatusError("Selected items have children, use C-c D for recursive deletion")
+				return m, nil
+			}
+		}
+	}
+
+	cur := m.workspace.Cursor()
+	next := cur
+	for next != nil && deleted(next) {
+		next = m.nextDisplayedRow(next)
+	}
+	if next == nil {
+		next = m.prevDisplayedRow(cur)
+		for next != nil && deleted(next) {
+			next = m.prevDisplayedRow(next)
+		}
+	}
+
+	if next == nil {
+		m.statusLine = m.renderStatusError("Cannot delete all the items")
+		return m, nil
+	}
+
+	for _, item := range items {
+		item.Trash()
+	}
+	m.clearSelection()
+
+	m.statusLine = ""
+	return m.moveCursor(next)
+}
+
+// summarizeSelection returns the number of the selected items followed
+// by the number of items in each status other than "None".
+func summarizeSelection(s selection) string {
+	counts := make(map[data.Status]int)
+	for item := range s {
+		counts[item.Status()]++
+	}
+
+	parts := []string{fmt.Sprintf("%d selected", len(s))}
+	for _, status := range []data.Status{
+		data.StatusToDo,
+		data.StatusDone,
+		data.StatusCanceled,
+		data.StatusWaiting,
+		data.StatusScheduled,
+	} {
+		if n := counts[status]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, strings.ToLower(status.String())))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
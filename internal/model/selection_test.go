@@ -0,0 +1,172 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestSummarizeSelection(t *testing.T) {
+	w := data.NewWorkspace("", "Root")
+
+	s := make(selection)
+	for _, status := range []data.Status{
+		data.StatusDone,
+		data.StatusNone,
+		data.StatusToDo,
+		data.StatusDone,
+		data.StatusNone,
+		data.StatusToDo,
+		data.StatusDone,
+	} {
+		item := w.NewItem("")
+		item.SetStatus(status)
+		s[item] = struct{}{}
+	}
+
+	assert.Equal(t, "7 selected, 2 todo, 3 done", summarizeSelection(s))
+	assert.Equal(t, "0 selected", summarizeSelection(selection{}))
+}
+
+func TestToggleSelected(t *testing.T) {
+	m, a, b, _ := newTestOutline(t)
+	b.SetStatus(data.StatusDone)
+
+	press(m, key(tea.KeyCtrlAt), key(tea.KeyCtrlDown), key(tea.KeyCtrlAt))
+	assert.Len(t, m.selection, 2)
+	assert.Contains(t, m.View(), "2 selected, 1 done")
+
+	press(m, key(tea.KeyCtrlUp), key(tea.KeyCtrlAt))
+	assert.NotContains(t, m.selection, a)
+	assert.Contains(t, m.View(), "1 selected, 1 done")
+
+	press(m, key(tea.KeyEsc))
+	assert.Empty(t, m.selection)
+	assert.NotContains(t, m.View(), "selected")
+}
+
+func TestSelectedItems(t *testing.T) {
+	m, a, _, c := newTestOutline(t)
+	d := m.workspace.NewItem("ChildD")
+	a.Append(d)
+
+	m.selection[c] = struct{}{}
+	m.selection[d] = struct{}{}
+	m.selection[a] = struct{}{}
+	assert.Equal(t, []*data.Item{a, d, c}, m.selectedItems(false))
+
+	// the descendants of the selected items are processed with them
+	assert.Equal(t, []*data.Item{a, c}, m.selectedItems(true))
+
+	// the detached items are left out
+	c.Detach()
+	assert.Equal(t, []*data.Item{a}, m.selectedItems(true))
+
+	m.clearSelection()
+	assert.Equal(t, []*data.Item{a}, m.targets(true))
+}
+
+func TestSelectionHighlight(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI)
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+
+	m, _, b, _ := newTestOutline(t)
+	selected := m.styles.itemSelected.Inherit(m.styles.itemNormal).Render("ChildB")
+
+	assert.NotContains(t, m.renderItemEntry(b), selected)
+
+	m.selection[b] = struct{}{}
+	assert.Contains(t, m.renderItemEntry(b), selected)
+}
+
+func TestSelectionSetStatus(t *testing.T) {
+	m, a, b, c := newTestOutline(t)
+	d := m.workspace.NewItem("ChildD")
+	b.Append(d)
+	m.selection[b] = struct{}{}
+	m.selection[c] = struct{}{}
+
+	press(m, key(tea.KeyCtrlC), runes("s"), runes("t"))
+	assert.Equal(t, data.StatusNone, a.Status())
+	assert.Equal(t, data.StatusToDo, b.Status())
+	assert.Equal(t, data.StatusNone, d.Status())
+	assert.Equal(t, data.StatusToDo, c.Status())
+	assert.Len(t, m.selection, 2)
+
+	press(m, key(tea.KeyCtrlC), runes("s"), runes("r"), runes("d"))
+	assert.Equal(t, data.StatusNone, a.Status())
+	for _, item := range []*data.Item{b, c, d} {
+		assert.Equal(t, data.StatusDone, item.Status(), item.Title())
+	}
+
+	// a single undo step for the whole selection
+	press(m, key(tea.KeyCtrlZ))
+	for _, item := range []*data.Item{b, c} {
+		assert.Equal(t, data.StatusToDo, item.Status(), item.Title())
+	}
+}
+
+func TestSelectionDelete(t *testing.T) {
+	t.Run("Leaves", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+		m.selection[a] = struct{}{}
+		m.selection[b] = struct{}{}
+
+		press(m, key(tea.KeyCtrlC), runes("d"))
+		assert.Equal(t, []*data.Item{c}, m.workspace.Root().DisplayedChildren())
+		assert.Same(t, c, m.workspace.Cursor())
+		assert.Empty(t, m.selection)
+
+		press(m, key(tea.KeyCtrlZ))
+		assert.Equal(t, []*data.Item{a, b, c}, m.workspace.Root().DisplayedChildren())
+	})
+
+	t.Run("AncestorAndDescendant", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+		d := m.workspace.NewItem("ChildD")
+		c.Append(d)
+		m.moveCursor(d)
+		m.selection[c] = struct{}{}
+		m.selection[d] = struct{}{}
+
+		press(m, key(tea.KeyCtrlC), runes("d"))
+		assert.Contains(t, m.statusLine, "Selected items have children")
+		assert.Len(t, m.selection, 2)
+
+		press(m, key(tea.KeyCtrlC), runes("D"))
+		assert.Equal(t, []*data.Item{a, b}, m.workspace.Root().DisplayedChildren())
+		assert.Same(t, c, d.Parent())
+		assert.Same(t, b, m.workspace.Cursor())
+	})
+
+	t.Run("All", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+		for _, item := range []*data.Item{a, b, c} {
+			m.selection[item] = struct{}{}
+		}
+
+		press(m, key(tea.KeyCtrlC), runes("d"))
+		assert.Contains(t, m.statusLine, "Cannot delete all the items")
+		assert.Equal(t, []*data.Item{a, b, c}, m.workspace.Root().DisplayedChildren())
+	})
+}
@@ -14,7 +14,14 @@
 
 package model
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
 
 const (
 	black   = lipgloss.ANSIColor(0)
@@ -28,74 +35,335 @@ const (
 	grey    = lipgloss.ANSIColor(8)
 )
 
-var (
-	styleBreadcrumbs = lipgloss.NewStyle().
-				Foreground(grey).
-				Italic(true).
-				PaddingLeft(1)
+// Theme holds the colors the styles are built from.
+type Theme struct {
+	// secondary text, such as the breadcrumbs and the completed items
+	Muted lipgloss.TerminalColor
 
-	styleBreadcrumbHighlited = lipgloss.NewStyle().
-					Foreground(magenta)
+	// whether the auxiliary text, such as the due dates and the child
+	// counts, is dimmed
+	Faint bool
 
-	styleItemNormal = lipgloss.NewStyle()
+	// whether the completed items are struck through
+	StrikeComplete bool
 
-	styleItemComplete = lipgloss.NewStyle().
-				Foreground(grey)
+	// highlighted text, such as the zoomed item title and the headings
+	Accent lipgloss.TerminalColor
 
-	styleTodoStats = lipgloss.NewStyle().
-			PaddingLeft(1).
-			Foreground(grey)
+	// tags and key names
+	Info lipgloss.TerminalColor
 
-	styleStatusLineError = lipgloss.NewStyle().
-				Background(red).
-				Foreground(white).
-				Padding(0, 1)
+	Note lipgloss.TerminalColor
 
-	styleStatusLineMessage = lipgloss.NewStyle().
-				Background(blue).
-				Foreground(white).
-				Padding(0, 1)
+	// background of the overdue item titles
+	Overdue lipgloss.TerminalColor
 
-	styleStatusLineHint = lipgloss.NewStyle().
-				Reverse(true).
-				Padding(0, 1)
+	// status line message colors
+	StatusLineText    lipgloss.TerminalColor
+	StatusLineError   lipgloss.TerminalColor
+	StatusLineMessage lipgloss.TerminalColor
 
-	styleItemStatus = []lipgloss.Style{
-		lipgloss.NewStyle().PaddingRight(1), // NONE
+	// colors indexed by data.Status and data.Priority values
+	Statuses   [6]lipgloss.TerminalColor
+	Priorities [4]lipgloss.TerminalColor
 
-		lipgloss.NewStyle().PaddingRight(1).Foreground(red), // TODO
+	// bullet colors by the item depth, repeated for the deeper items
+	Bullets []lipgloss.TerminalColor
+}
 
-		lipgloss.NewStyle().PaddingRight(1).Foreground(green), // DONE
+const defaultThemeName = "default"
 
-		lipgloss.NewStyle().PaddingRight(1).Foreground(blue), // CANC
+var themes = map[string]Theme{
+	defaultThemeName: {
+		Muted:             grey,
+		Faint:             true,
+		Accent:            magenta,
+		Info:              cyan,
+		Note:              yellow,
+		Overdue:           red,
+		StatusLineText:    white,
+		StatusLineError:   red,
+		StatusLineMessage: blue,
+		Statuses:          [6]lipgloss.TerminalColor{lipgloss.NoColor{}, red, green, blue, cyan, magenta},
+		Priorities:        [4]lipgloss.TerminalColor{lipgloss.NoColor{}, red, yellow, blue},
+		Bullets:           []lipgloss.TerminalColor{lipgloss.NoColor{}, green, cyan, blue, magenta, red},
+	},
+
+	// no grey and no faint text, readable on the light backgrounds too
+	"high-contrast": {
+		Muted:             lipgloss.NoColor{},
+		StrikeComplete:    true,
+		Accent:            blue,
+		Info:              blue,
+		Note:              magenta,
+		Overdue:           red,
+		StatusLineText:    white,
+		StatusLineError:   red,
+		StatusLineMessage: blue,
+		Statuses:          [6]lipgloss.TerminalColor{lipgloss.NoColor{}, red, green, blue, magenta, magenta},
+		Priorities:        [4]lipgloss.TerminalColor{lipgloss.NoColor{}, red, magenta, blue},
+		Bullets:           []lipgloss.TerminalColor{lipgloss.NoColor{}},
+	},
+}
+
+// styles are the lipgloss styles built from a theme. Every outline
+// holds its own, so the outlines with different themes do not
+// affect each other.
+type styles struct {
+	breadcrumbs         lipgloss.Style
+	breadcrumbHighlited lipgloss.Style
+	filterIndicator     lipgloss.Style
+	unsavedIndicator    lipgloss.Style
+	itemNormal          lipgloss.Style
+	itemComplete        lipgloss.Style
+	itemOverdue         lipgloss.Style
+	searchMatch         lipgloss.Style
+	itemSelected        lipgloss.Style
+	todoStats           lipgloss.Style
+	noteIndicator       lipgloss.Style
+	linkIndicator       lipgloss.Style
+	due                 lipgloss.Style
+	completed           lipgloss.Style
+	childCount          lipgloss.Style
+	tags                lipgloss.Style
+	scrollIndicator     lipgloss.Style
+	separator           lipgloss.Style
+	indentGuide         lipgloss.Style
+	statusLineError     lipgloss.Style
+	statusLineMessage   lipgloss.Style
+	statusLineHint      lipgloss.Style
+	palettePath         lipgloss.Style
+	paletteSelected     lipgloss.Style
+	helpPanel           lipgloss.Style
+	helpTitle           lipgloss.Style
+	helpKey             lipgloss.Style
+	dueGroup            lipgloss.Style
+	itemStatus          []lipgloss.Style
+	priority            []lipgloss.Style
+	bullet              []lipgloss.Style
+}
+
+var (
+	// Styles used instead of the colored ones when the terminal
+	// has no colors
+	styleItemCompleteMono = lipgloss.NewStyle().
+				Faint(true)
 
-		lipgloss.NewStyle().PaddingRight(1).Foreground(cyan), // WAIT
+	styleItemOverdueMono = lipgloss.NewStyle().
+				Reverse(true)
 
-		lipgloss.NewStyle().PaddingRight(1).Foreground(magenta), // SCHD
+	styleStatusLineErrorMono = lipgloss.NewStyle().
+					Bold(true).
+					Reverse(true).
+					Padding(0, 1)
+
+	styleStatusLineMessageMono = lipgloss.NewStyle().
+					Reverse(true).
+					Padding(0, 1)
+)
+
+// newThemeStyles builds the styles from the theme with the given name.
+func newThemeStyles(name string) (*styles, error) {
+	t, ok := themes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown theme %q", name)
 	}
 
-	styleBullet = []lipgloss.Style{
-		lipgloss.NewStyle().
-			Padding(0, 1),
+	return newStyles(t), nil
+}
+
+// newStyles builds the styles from the theme.
+func newStyles(t Theme) *styles {
+	s := &styles{}
+
+	s.breadcrumbs = lipgloss.NewStyle().
+		Foreground(t.Muted).
+		Italic(true).
+		PaddingLeft(1)
+
+	s.breadcrumbHighlited = lipgloss.NewStyle().
+		Foreground(t.Accent)
+
+	s.filterIndicator = lipgloss.NewStyle().
+		Foreground(t.Info).
+		PaddingLeft(1)
+
+	s.unsavedIndicator = lipgloss.NewStyle().
+		Foreground(t.Accent).
+		PaddingRight(1)
+
+	s.itemNormal = lipgloss.NewStyle()
+
+	s.itemComplete = lipgloss.NewStyle().
+		Foreground(t.Muted).
+		Strikethrough(t.StrikeComplete)
+
+	// the overdue titles get a background, so the status labels
+	// keep their colors next to them
+	s.itemOverdue = lipgloss.NewStyle().
+		Background(t.Overdue).
+		Foreground(t.StatusLineText)
+
+	s.searchMatch = lipgloss.NewStyle().
+		Reverse(true)
+
+	s.itemSelected = lipgloss.NewStyle().
+		Bold(true).
+		Underline(true)
+
+	s.todoStats = lipgloss.NewStyle().
+		PaddingLeft(1).
+		Foreground(t.Muted)
+
+	s.noteIndicator = lipgloss.NewStyle().
+		PaddingLeft(1).
+		Foreground(t.Note)
+
+	s.linkIndicator = lipgloss.NewStyle().
+		PaddingLeft(1).
+		Foreground(t.Info)
+
+	s.due = lipgloss.NewStyle().
+		PaddingLeft(1).
+		Faint(t.Faint)
+
+	s.completed = lipgloss.NewStyle().
+		PaddingLeft(1).
+		Faint(t.Faint)
+
+	s.childCount = lipgloss.NewStyle().
+		PaddingLeft(1).
+		Faint(t.Faint)
+
+	s.tags = lipgloss.NewStyle().
+		PaddingLeft(1).
+		Foreground(t.Info).
+		Italic(true)
+
+	s.scrollIndicator = lipgloss.NewStyle().
+		Faint(t.Faint)
+
+	s.separator = lipgloss.NewStyle().
+		Faint(t.Faint)
 
-		lipgloss.NewStyle().
-			Foreground(green).
-			Padding(0, 1),
+	s.indentGuide = lipgloss.NewStyle().
+		Foreground(t.Muted).
+		Faint(t.Faint)
 
-		lipgloss.NewStyle().
-			Foreground(cyan).
-			Padding(0, 1),
+	s.statusLineError = lipgloss.NewStyle().
+		Background(t.StatusLineError).
+		Foreground(t.StatusLineText).
+		Padding(0, 1)
 
-		lipgloss.NewStyle().
-			Foreground(blue).
-			Padding(0, 1),
+	s.statusLineMessage = lipgloss.NewStyle().
+		Background(t.StatusLineMessage).
+		Foreground(t.StatusLineText).
+		Padding(0, 1)
 
-		lipgloss.NewStyle().
-			Foreground(magenta).
-			Padding(0, 1),
+	s.statusLineHint = lipgloss.NewStyle().
+		Reverse(true).
+		Padding(0, 1)
 
-		lipgloss.NewStyle().
-			Foreground(red).
-			Padding(0, 1),
+	s.palettePath = lipgloss.NewStyle().
+		Foreground(t.Muted).
+		Italic(true)
+
+	s.paletteSelected = lipgloss.NewStyle().
+		Reverse(true)
+
+	s.helpPanel = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		Padding(0, 1)
+
+	s.helpTitle = lipgloss.NewStyle().
+		Foreground(t.Accent).
+		Bold(true)
+
+	s.helpKey = lipgloss.NewStyle().
+		Foreground(t.Info)
+
+	s.dueGroup = lipgloss.NewStyle().
+		Foreground(t.Accent).
+		Bold(true)
+
+	s.itemStatus = make([]lipgloss.Style, len(t.Statuses))
+	for i, c := range t.Statuses {
+		s.itemStatus[i] = lipgloss.NewStyle().PaddingRight(1).Foreground(c)
+	}
+
+	// the priorities other than "None" are rendered as markers
+	s.priority = make([]lipgloss.Style, len(t.Priorities))
+	for i, c := range t.Priorities[1:] {
+		s.priority[i+1] = lipgloss.NewStyle().PaddingRight(1).Foreground(c)
 	}
+	s.priority[data.PriorityA] = s.priority[data.PriorityA].Bold(true)
+	s.priority[data.PriorityB] = s.priority[data.PriorityB].Bold(true)
+
+	s.bullet = make([]lipgloss.Style, len(t.Bullets))
+	for i, c := range t.Bullets {
+		s.bullet[i] = lipgloss.NewStyle().
+			Foreground(c).
+			Padding(0, 1)
+	}
+
+	return s
+}
+
+const (
+	monoErrorPrefix   = "ERR: "
+	monoMessagePrefix = "OK: "
+	monoOverdueMarker = "! "
 )
+
+// monochrome is set when the terminal can not render colors, so
+// the meaning of the colored styles is conveyed by text attributes
+// and prefixes instead. The terminals without colors may drop the
+// attributes too, so the prefixes and markers carry the meaning on
+// their own.
+var monochrome = lipgloss.ColorProfile() == termenv.Ascii
+
+func (s *styles) getItemCompleteStyle() lipgloss.Style {
+	if monochrome {
+		return styleItemCompleteMono
+	}
+
+	return s.itemComplete
+}
+
+func (s *styles) getItemOverdueStyle() lipgloss.Style {
+	if monochrome {
+		return styleItemOverdueMono
+	}
+
+	return s.itemOverdue
+}
+
+// getOverdueMarker returns the text put before the title of an overdue
+// item, or an empty string if the overdue style is colored. The
+// completed items are told by their status labels.
+func getOverdueMarker() string {
+	if monochrome {
+		return monoOverdueMarker
+	}
+
+	return ""
+}
+
+// renderStatusError renders an error message for the status line.
+func (m *Outline) renderStatusError(msg string) string {
+	if monochrome {
+		return styleStatusLineErrorMono.Render(monoErrorPrefix + msg)
+	}
+
+	return m.styles.statusLineError.Render(msg)
+}
+
+// renderStatusMessage renders an informational message for the status
+// line.
+func (m *Outline) renderStatusMessage(msg string) string {
+	if monochrome {
+		return styleStatusLineMessageMono.Render(monoMessagePrefix + msg)
+	}
+
+	return m.styles.statusLineMessage.Render(msg)
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/config"
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func setMonochrome(t *testing.T, value bool) {
+	t.Helper()
+
+	saved := monochrome
+	monochrome = value
+	t.Cleanup(func() { monochrome = saved })
+}
+
+func TestMonochromeStyles(t *testing.T) {
+	t.Run("Color", func(t *testing.T) {
+		setMonochrome(t, false)
+		m, _, _, _ := newTestOutline(t)
+
+		assert.NotContains(t, m.renderStatusError("failed"), monoErrorPrefix)
+		assert.NotContains(t, m.renderStatusMessage("saved"), monoMessagePrefix)
+		assert.Equal(t, m.styles.itemComplete, m.styles.getItemCompleteStyle())
+		assert.Equal(t, m.styles.itemOverdue, m.styles.getItemOverdueStyle())
+	})
+
+	t.Run("Monochrome", func(t *testing.T) {
+		setMonochrome(t, true)
+		m, a, b, _ := newTestOutline(t)
+
+		assert.Contains(t, m.renderStatusError("failed"), monoErrorPrefix+"failed")
+		assert.Contains(t, m.renderStatusMessage("saved"), monoMessagePrefix+"saved")
+
+		assert.True(t, styleStatusLineErrorMono.GetReverse())
+		assert.True(t, styleStatusLineErrorMono.GetBold())
+		assert.True(t, styleStatusLineMessageMono.GetReverse())
+		assert.True(t, m.styles.getItemOverdueStyle().GetReverse())
+
+		b.SetStatus(data.StatusDone)
+		assert.True(t, m.getItemStyle(b).GetFaint())
+		assert.False(t, m.getItemStyle(a).GetFaint())
+
+		m.save()
+		assert.Contains(t, m.statusLine, monoMessagePrefix)
+	})
+
+	t.Run("Rows", func(t *testing.T) {
+		// the terminals without colors drop the text attributes too
+		profile := lipgloss.ColorProfile()
+		lipgloss.SetColorProfile(termenv.Ascii)
+		t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+		setMonochrome(t, true)
+
+		now := time.Date(2025, 6, 14, 10, 0, 0, 0, time.Local)
+		m, _, b, c := newTestOutline(t)
+		m.now = func() time.Time { return now }
+		b.SetStatus(data.StatusToDo)
+		normal := m.renderItemEntry(b)
+
+		b.SetDue(now.Add(-24 * time.Hour))
+		overdue := m.renderItemEntry(b)
+		assert.NotEqual(t, normal, overdue)
+		assert.Contains(t, overdue, monoOverdueMarker+"ChildB")
+
+		c.SetStatus(data.StatusDone)
+		assert.Contains(t, m.renderItemEntry(c), data.StatusDone.String())
+		assert.NotContains(t, m.renderItemEntry(c), monoOverdueMarker)
+	})
+}
+
+func TestThemes(t *testing.T) {
+	newThemeOutline := func(t *testing.T, theme string) (*Outline, error) {
+		cfg := config.Default()
+		cfg.Theme = theme
+		return NewOutline(data.NewWorkspace(t.TempDir(), "Root"), cfg)
+	}
+
+	contrast, err := newThemeOutline(t, "high-contrast")
+	require.NoError(t, err)
+	assert.True(t, contrast.styles.itemComplete.GetStrikethrough())
+	assert.False(t, contrast.styles.due.GetFaint())
+	assert.Equal(t, blue, contrast.styles.itemStatus[data.StatusCanceled].GetForeground())
+
+	// the outlines do not share the styles
+	def, err := newThemeOutline(t, defaultThemeName)
+	require.NoError(t, err)
+	assert.False(t, def.styles.itemComplete.GetStrikethrough())
+	assert.True(t, def.styles.due.GetFaint())
+	assert.True(t, contrast.styles.itemComplete.GetStrikethrough())
+
+	_, err = newThemeOutline(t, "neon")
+	assert.ErrorContains(t, err, `unknown theme "neon"`)
+}
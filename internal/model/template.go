@@ -0,0 +1,46 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+// openTemplatePicker lists the templates and inserts the chosen one.
+func (m *Outline) openTemplatePicker() (tea.Model, tea.Cmd) {
+	templates, err := data.ListTemplates(m.workspace.Directory())
+	if err != nil {
+		m.statusLine = m.renderStatusError(err.Error())
+		return m, nil
+	}
+
+	return m.openPicker("insert template: ", templates, (*Outline).insertTemplate), nil
+}
+
+// insertTemplate inserts the subtree of the named template below the
+// cursor and moves the cursor to it.
+func (m *Outline) insertTemplate(name string) (tea.Model, tea.Cmd) {
+	item, err := m.workspace.LoadTemplate(name)
+	if err != nil {
+		m.statusLine = m.renderStatusError(err.Error())
+		return m, nil
+	}
+
+	item.MoveBelow(m.workspace.Cursor())
+
+	return m.moveCursor(item)
+}
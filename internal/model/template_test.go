@@ -0,0 +1,54 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertTemplate(t *testing.T) {
+	m, a, b, _ := newTestOutline(t)
+
+	model := press(m, key(tea.KeyCtrlC), runes("i"))
+	require.IsType(t, pickerMode{}, model)
+	assert.Contains(t, ansi.Strip(model.View()), "daily")
+
+	model = press(model, runes("dai"), key(tea.KeyEnter))
+	assert.Same(t, m, model)
+
+	// the template subtree is grafted below the cursor
+	daily := a.Next()
+	require.NotNil(t, daily)
+	assert.Same(t, b, daily.Next())
+	assert.Same(t, daily, m.workspace.Cursor())
+	assert.Equal(t, "Daily", m.textInput.Value())
+
+	var shape []string
+	for c := daily.Head(); c != nil; c = c.Next() {
+		shape = append(shape, c.Title())
+		for gc := c.Head(); gc != nil; gc = gc.Next() {
+			shape = append(shape, "  "+gc.Title())
+		}
+	}
+	assert.Equal(t, []string{"Top priorities", "  ", "Meetings", "Notes", "Review", "  Plan tomorrow"}, shape)
+
+	press(m, key(tea.KeyCtrlZ))
+	assert.Same(t, b, a.Next())
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// titleCase upper-cases the first letter of every word and lower-cases
+// the rest. Words are separated by white space.
+func titleCase(s string) string {
+	var b strings.Builder
+	wordStart := true
+
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			wordStart = true
+			b.WriteRune(r)
+			continue
+		}
+
+		if wordStart {
+			b.WriteRune(unicode.ToTitle(r))
+		} else {
+			b.WriteRune(unicode.ToLower(r))
+		}
+		wordStart = false
+	}
+
+	return b.String()
+}
+
+// cycleCase returns the next case variant of s in the cycle
+// lower case -> Title Case -> UPPER CASE -> lower case. Mixed case
+// strings start the cycle with lower case.
+func cycleCase(s string) string {
+	lower := strings.ToLower(s)
+	upper := strings.ToUpper(s)
+	title := titleCase(s)
+
+	switch {
+	case s == lower && s != title:
+		return title
+	case s == title && s != upper:
+		return upper
+	default:
+		return lower
+	}
+}
+
+func (m *Outline) cycleTitleCase() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+
+	cur := m.workspace.Cursor()
+	pos := m.textInput.Position()
+
+	cur.SetTitle(cycleCase(cur.Title()))
+	m.updateTextInput(cur)
+	m.textInput.SetCursor(pos)
+
+	return m, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCycleCase(t *testing.T) {
+	t.Run("Ascii", func(t *testing.T) {
+		s := "buy milk"
+
+		s = cycleCase(s)
+		assert.Equal(t, "Buy Milk", s)
+
+		s = cycleCase(s)
+		assert.Equal(t, "BUY MILK", s)
+
+		s = cycleCase(s)
+		assert.Equal(t, "buy milk", s)
+	})
+
+	t.Run("MixedUnicode", func(t *testing.T) {
+		s := "cAFÉ ñandú ЁЖИК straße"
+
+		s = cycleCase(s)
+		assert.Equal(t, "café ñandú ёжик straße", s)
+
+		s = cycleCase(s)
+		assert.Equal(t, "Café Ñandú Ёжик Straße", s)
+
+		s = cycleCase(s)
+		assert.Equal(t, "CAFÉ ÑANDÚ ЁЖИК STRAßE", s)
+	})
+
+	t.Run("TitleCaseDigraph", func(t *testing.T) {
+		assert.Equal(t, "ǅem", titleCase("ǆem"))
+	})
+
+	t.Run("NoLetters", func(t *testing.T) {
+		assert.Equal(t, "42 - 7", cycleCase("42 - 7"))
+	})
+}
+
+func TestCycleTitleCase(t *testing.T) {
+	m, a, b, _ := newTestOutline(t)
+
+	press(m, key(tea.KeyCtrlC), runes("c"))
+	assert.Equal(t, "childa", a.Title())
+	assert.Equal(t, "childa", m.textInput.Value())
+
+	// the change is repeated on the next item
+	press(m, key(tea.KeyCtrlDown), key(tea.KeyCtrlR))
+	assert.Equal(t, "childb", b.Title())
+	assert.Equal(t, "childb", m.textInput.Value())
+
+	press(m, key(tea.KeyCtrlZ))
+	assert.Equal(t, "ChildB", b.Title())
+}
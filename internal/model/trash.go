@@ -0,0 +1,122 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+const trashTimeLayout = "2006-01-02 15:04"
+
+// trashMode lists the deleted items, the most recent first, and
+// restores the selected one or empties the trash.
+type trashMode struct {
+	*Outline
+
+	items    []*data.Item
+	selected int
+}
+
+func (m *Outline) openTrash() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+	m.statusLine = ""
+
+	return trashMode{Outline: m, items: m.workspace.Trashed()}, nil
+}
+
+func (m trashMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m.fileChanged(m)
+	case tea.WindowSizeMsg:
+		m.updateWindowSize(msg)
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEsc:
+			return m.Outline, nil
+		case tea.KeyUp:
+			m.selected = max(m.selected-1, 0)
+			return m, nil
+		case tea.KeyDown:
+			m.selected = max(min(m.selected+1, len(m.items)-1), 0)
+			return m, nil
+		}
+
+		switch m.keys.trash.match(msg) {
+		case "restoreTrashed":
+			if len(m.items) == 0 {
+				return m, nil
+			}
+
+			item := m.items[m.selected]
+			item.Restore()
+
+			m.Outline.statusLine = m.renderStatusMessage("Restored " + item.Title())
+			return m.reveal(item)
+		case "emptyTrash":
+			n := m.workspace.EmptyTrash()
+
+			m.Outline.statusLine = m.renderStatusMessage(fmt.Sprintf("Removed %d items from the trash", n))
+			return m.Outline, nil
+		}
+	}
+
+	return m, nil
+}
+
+func (m trashMode) View() string {
+	if m.windowWidth == 0 || m.windowHeight == 0 {
+		return ""
+	}
+
+	header := m.keys.trash.menu() + "  [esc] close"
+	if len(m.items) == 0 {
+		header += " (empty)"
+	}
+
+	var rows []string
+	for idx, item := range m.items {
+		deleted := item.Deleted().Format(trashTimeLayout) + "  "
+
+		row := runewidth.Truncate(deleted+item.Title(), m.windowWidth, "...")
+		if idx == m.selected {
+			row = m.styles.paletteSelected.Render(row)
+		} else if len(row) > len(deleted) {
+			row = m.styles.palettePath.Render(deleted) + row[len(deleted):]
+		}
+
+		rows = append(rows, row)
+	}
+
+	listHeight := m.windowHeight - 1
+	offset := clampOffset(m.selected-listHeight+1, len(rows), listHeight)
+	rows = rows[offset:min(offset+listHeight, len(rows))]
+
+	return lipgloss.Place(
+		m.windowWidth,
+		m.windowHeight,
+		lipgloss.Left,
+		lipgloss.Top,
+		lipgloss.JoinVertical(lipgloss.Left, append([]string{header}, rows...)...),
+	)
+}
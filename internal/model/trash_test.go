@@ -0,0 +1,72 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrash(t *testing.T) {
+	t.Run("Restore", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+		child := m.workspace.NewItem("Child")
+		b.Append(child)
+
+		press(m, key(tea.KeyCtrlC), runes("d"))
+		assert.Same(t, b, m.workspace.Cursor())
+		press(m, key(tea.KeyCtrlDown), key(tea.KeyCtrlDown), key(tea.KeyCtrlC), runes("d"))
+		assert.Same(t, b, m.workspace.Cursor())
+
+		model := press(m, key(tea.KeyCtrlX), runes("T"))
+		require.IsType(t, trashMode{}, model)
+		view := ansi.Strip(model.View())
+		assert.Contains(t, view, "trash: [r]estore  [E]mpty trash")
+		assert.Contains(t, view, "ChildC")
+		assert.Contains(t, view, "ChildA")
+
+		model = press(model, key(tea.KeyDown), key(tea.KeyEnter))
+		assert.Same(t, m, model)
+		assert.Same(t, a, m.workspace.Cursor())
+		assert.Same(t, m.workspace.Root(), a.Parent())
+		assert.Same(t, b, a.Prev())
+		assert.True(t, a.Deleted().IsZero())
+		assert.Contains(t, m.statusLine, "Restored ChildA")
+
+		press(m, key(tea.KeyCtrlZ))
+		assert.False(t, a.Deleted().IsZero())
+		assert.Contains(t, m.workspace.Trashed(), a)
+		assert.Contains(t, m.workspace.Trashed(), c)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+		press(m, key(tea.KeyCtrlC), runes("d"))
+
+		model := press(m, key(tea.KeyCtrlX), runes("T"), runes("E"))
+		assert.Same(t, m, model)
+		assert.Empty(t, m.workspace.Trashed())
+		assert.Nil(t, a.Parent())
+		assert.Contains(t, m.statusLine, "Removed 1 items from the trash")
+
+		model = press(m, key(tea.KeyCtrlX), runes("T"))
+		assert.Contains(t, ansi.Strip(model.View()), "(empty)")
+		assert.Same(t, m, press(model, key(tea.KeyEsc)))
+	})
+}
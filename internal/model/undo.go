@@ -0,0 +1,59 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+// undoable performs the action recording all its changes as a single
+// undo step. The pending title edit is recorded as a step of its own.
+func (m *Outline) undoable(a action) (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+
+	m.workspace.BeginBatch()
+	defer m.workspace.EndBatch()
+
+	return a(m)
+}
+
+func (m *Outline) undo() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+	return m.restoreHistory(m.workspace.Undo(), "Nothing to undo")
+}
+
+func (m *Outline) redo() (tea.Model, tea.Cmd) {
+	m.saveCurrentTitle()
+	return m.restoreHistory(m.workspace.Redo(), "Nothing to redo")
+}
+
+// restoreHistory places the cursor on the item returned by the undo
+// or redo.
+func (m *Outline) restoreHistory(item *data.Item, noop string) (tea.Model, tea.Cmd) {
+	if item == nil {
+		m.statusLine = m.renderStatusError(noop)
+		return m, nil
+	}
+
+	m.statusLine = ""
+
+	// the title in the text input might be the undone one
+	m.updateTextInput(m.workspace.Cursor())
+
+	m.reveal(item)
+	return m.revealCursor()
+}
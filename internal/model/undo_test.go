@@ -0,0 +1,77 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestUndoRedo(t *testing.T) {
+	t.Run("Delete", func(t *testing.T) {
+		m, a, b, c := newTestOutline(t)
+
+		press(m, key(tea.KeyCtrlDown), key(tea.KeyCtrlC), runes("d"))
+		assert.Equal(t, []*data.Item{b}, m.workspace.Trashed())
+		assert.Same(t, c, m.workspace.Cursor())
+
+		press(m, key(tea.KeyCtrlZ))
+		assert.Same(t, a, b.Prev())
+		assert.Same(t, b, m.workspace.Cursor())
+		assert.Equal(t, "ChildB", m.textInput.Value())
+
+		press(m, key(tea.KeyCtrlY))
+		assert.Equal(t, []*data.Item{b}, m.workspace.Trashed())
+		assert.Same(t, c, m.workspace.Cursor())
+	})
+
+	t.Run("AddSibling", func(t *testing.T) {
+		m, a, b, _ := newTestOutline(t)
+
+		// the title edit is undone first, then the item itself
+		press(m, key(tea.KeyTab), runes("New"), key(tea.KeyCtrlZ))
+		added := a.Next()
+		assert.Same(t, added, m.workspace.Cursor())
+		assert.Equal(t, "", added.Title())
+
+		press(m, key(tea.KeyCtrlZ))
+		assert.Same(t, b, a.Next())
+		assert.Nil(t, added.Parent())
+		assert.Same(t, b, m.workspace.Cursor())
+	})
+
+	t.Run("TitleEdit", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+
+		press(m, runes("!"), key(tea.KeyCtrlZ))
+		assert.Equal(t, "ChildA", a.Title())
+		assert.Equal(t, "ChildA", m.textInput.Value())
+
+		press(m, key(tea.KeyCtrlY))
+		assert.Equal(t, "ChildA!", a.Title())
+		assert.Equal(t, "ChildA!", m.textInput.Value())
+	})
+
+	t.Run("NothingToUndo", func(t *testing.T) {
+		m, _, _, _ := newTestOutline(t)
+
+		press(m, key(tea.KeyCtrlY))
+		assert.Contains(t, m.statusLine, "Nothing to redo")
+	})
+}
@@ -0,0 +1,405 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-runewidth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/config"
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+func TestChildCountBadge(t *testing.T) {
+	m, a, b, c := newTestOutline(t)
+	a.Append(b)
+	a.Append(c)
+
+	assert.Empty(t, m.getChildCountBadge(a))
+
+	press(m, key(tea.KeyCtrlX), runes("v"), runes("c"))
+	assert.True(t, m.showChildCount)
+
+	assert.Contains(t, m.getChildCountBadge(a), "[2]")
+	assert.Empty(t, m.getChildCountBadge(b))
+
+	withBadge := m.getMaxTitleWidth(a)
+	m.showChildCount = false
+	assert.Equal(t, m.getMaxTitleWidth(a)-len(" [2]"), withBadge)
+}
+
+func TestTagsRendering(t *testing.T) {
+	m, a, b, _ := newTestOutline(t)
+	a.AddTag("@work")
+	a.AddTag("#urgent")
+
+	row := m.renderItemEntry(a)
+	assert.Contains(t, row, "#urgent @work")
+	assert.Less(t, strings.Index(row, "ChildA"), strings.Index(row, "#urgent"))
+
+	assert.NotContains(t, m.renderItemEntry(b), "@")
+}
+
+func TestAutoExpand(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		m, _, b, c := newTestOutline(t)
+		b.Append(m.workspace.NewItem("Nested"))
+		b.SetCollapsed(true, false)
+
+		press(m, key(tea.KeyCtrlDown))
+		assert.True(t, b.Collapsed())
+
+		press(m, key(tea.KeyCtrlDown))
+		assert.Same(t, c, m.workspace.Cursor())
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		m, _, b, _ := newTestOutline(t)
+		nested := m.workspace.NewItem("Nested")
+		b.Append(nested)
+		b.SetCollapsed(true, false)
+
+		press(m, key(tea.KeyCtrlX), runes("v"), runes("e"))
+		assert.True(t, m.autoExpand)
+
+		press(m, key(tea.KeyCtrlDown))
+		assert.False(t, b.Collapsed())
+
+		// manual folding sticks while the cursor stays on the item
+		press(m, key(tea.KeyCtrlC), runes("f"))
+		assert.True(t, b.Collapsed())
+
+		press(m, key(tea.KeyCtrlUp), key(tea.KeyCtrlDown))
+		assert.False(t, b.Collapsed())
+
+		press(m, key(tea.KeyCtrlDown))
+		assert.Same(t, nested, m.workspace.Cursor())
+	})
+}
+
+func TestViewportScrolling(t *testing.T) {
+	m, a, b, c := newTestOutline(t)
+	for i := range 20 {
+		m.workspace.Root().Append(m.workspace.NewItem(fmt.Sprintf("Item%02d", i)))
+	}
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 10})
+
+	assert.Equal(t, 6, m.listHeight())
+	assert.Contains(t, m.View(), "Item02")
+	assert.NotContains(t, m.View(), "Item03")
+	assert.Contains(t, m.View(), "row 1 of 23")
+
+	for range 8 {
+		press(m, key(tea.KeyCtrlDown))
+	}
+
+	// the cursor is on Item05, the last visible row
+	assert.Equal(t, 3, m.offset)
+	assert.Same(t, m.workspace.Root().DisplayedChildren()[3], m.workspace.Top())
+	assert.NotContains(t, m.View(), "ChildC")
+	assert.Contains(t, m.View(), "Item05")
+	assert.Contains(t, m.View(), "row 9 of 23")
+
+	for range 6 {
+		press(m, key(tea.KeyCtrlUp))
+	}
+
+	// the cursor is on ChildC, the first visible row
+	assert.Same(t, c, m.workspace.Cursor())
+	assert.Equal(t, 2, m.offset)
+	assert.Contains(t, m.View(), "ChildC")
+	assert.NotContains(t, m.View(), "ChildB")
+
+	// removing the rows above the cursor keeps it visible
+	a.Detach()
+	b.Detach()
+	assert.Contains(t, m.View(), "ChildC")
+	assert.Equal(t, 0, m.offset)
+}
+
+func TestRestoreScroll(t *testing.T) {
+	t.Run("TopItem", func(t *testing.T) {
+		m, _, _, c := newTestOutline(t)
+		m.workspace.SetTop(c)
+
+		m, err := NewOutline(m.workspace, m.config)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, m.offset)
+	})
+
+	t.Run("ShrunkTree", func(t *testing.T) {
+		m, _, _, c := newTestOutline(t)
+		for i := range 20 {
+			m.workspace.Root().Append(m.workspace.NewItem(fmt.Sprintf("Item%02d", i)))
+		}
+		m.workspace.SetTop(m.workspace.Root().Tail())
+
+		// the rows below the top item no longer fill the window
+		m, err := NewOutline(m.workspace, m.config)
+		require.NoError(t, err)
+		m.Update(tea.WindowSizeMsg{Width: 80, Height: 10})
+		m.moveCursor(m.workspace.Root().Tail())
+
+		assert.Equal(t, 17, m.offset)
+		assert.Contains(t, m.View(), "Item19")
+		assert.Contains(t, m.View(), "Item14")
+		assert.NotContains(t, m.View(), "ChildC")
+
+		// the top item is gone, so the cursor line is centered
+		cur := m.workspace.Root().Head()
+		for range 10 {
+			cur = cur.Next()
+		}
+		require.Equal(t, "Item07", cur.Title())
+		m.workspace.SetCursor(cur)
+		m.workspace.SetTop(c)
+		c.Detach()
+
+		m, err = NewOutline(m.workspace, m.config)
+		require.NoError(t, err)
+		m.Update(tea.WindowSizeMsg{Width: 80, Height: 10})
+
+		idx := slices.Index(m.displayedLines(), cur)
+		require.Greater(t, idx, m.listHeight())
+		assert.Equal(t, idx-m.listHeight()/2, m.offset)
+		assert.Contains(t, m.View(), "Item07")
+		assert.NotContains(t, m.View(), "ChildA")
+	})
+}
+
+func TestWithSeparators(t *testing.T) {
+	m, a, b, c := newTestOutline(t)
+	root := m.workspace.Root()
+	nested := m.workspace.NewItem("Nested")
+	a.Append(nested)
+
+	lines := withSeparators([]*data.Item{a, nested, b, c}, root)
+	assert.Equal(t, []*data.Item{a, nested, nil, b, nil, c}, lines)
+
+	// rows of a zoomed view have the zoomed item children at the top level
+	assert.Equal(t, []*data.Item{nested}, withSeparators([]*data.Item{nested}, a))
+	assert.Empty(t, withSeparators(nil, root))
+}
+
+func TestSeparatorsViewport(t *testing.T) {
+	m, _, _, c := newTestOutline(t)
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 8})
+
+	press(m, key(tea.KeyCtrlX), runes("v"), runes("s"))
+	assert.True(t, m.showSeparators)
+	assert.Len(t, m.displayedLines(), 5)
+	assert.Contains(t, m.View(), strings.Repeat("─", 80))
+
+	// the cursor line is the fifth one, so the first line scrolls away
+	press(m, key(tea.KeyCtrlDown), key(tea.KeyCtrlDown))
+	assert.Same(t, c, m.workspace.Cursor())
+	assert.Equal(t, 1, m.offset)
+	assert.NotContains(t, m.View(), "ChildA")
+	assert.Contains(t, m.View(), "ChildC")
+	assert.Contains(t, m.View(), "row 3 of 3")
+}
+
+func TestWrapTitles(t *testing.T) {
+	const long = "alpha bravo charlie delta echo foxtrot golf hotel"
+
+	t.Run("Wrap", func(t *testing.T) {
+		m, _, b, _ := newTestOutline(t)
+		b.SetTitle(long)
+		m.Update(tea.WindowSizeMsg{Width: 30, Height: 24})
+
+		assert.Len(t, m.titleLines(b), 1)
+		assert.Contains(t, m.View(), "...")
+
+		press(m, key(tea.KeyCtrlX), runes("v"), runes("w"))
+		assert.True(t, m.wrapTitles)
+
+		lines := m.titleLines(b)
+		assert.Greater(t, len(lines), 1)
+		for _, l := range lines {
+			assert.LessOrEqual(t, runewidth.StringWidth(l), m.getMaxTitleWidth(b))
+		}
+		assert.Equal(t, long, strings.Join(lines, " "))
+		assert.Equal(t, len(lines), m.lineHeight(b))
+		assert.NotContains(t, m.View(), "...")
+		assert.Contains(t, m.View(), "hotel")
+
+		// the title under the cursor is edited in a single line
+		m.moveCursor(b)
+		assert.Equal(t, 1, m.lineHeight(b))
+	})
+
+	t.Run("DoubleWidth", func(t *testing.T) {
+		m, _, b, _ := newTestOutline(t)
+		b.SetTitle(strings.Repeat("漢字", 10))
+		m.Update(tea.WindowSizeMsg{Width: 30, Height: 24})
+		m.wrapTitles = true
+
+		lines := m.titleLines(b)
+		assert.Greater(t, len(lines), 1)
+		for _, l := range lines {
+			assert.LessOrEqual(t, runewidth.StringWidth(l), m.getMaxTitleWidth(b))
+		}
+		assert.Equal(t, b.Title(), strings.Join(lines, ""))
+	})
+
+	t.Run("Viewport", func(t *testing.T) {
+		m, _, b, c := newTestOutline(t)
+		b.SetTitle(long + " india juliet kilo lima")
+		m.Update(tea.WindowSizeMsg{Width: 30, Height: 8})
+		m.wrapTitles = true
+		require.Greater(t, m.lineHeight(b), 2)
+
+		// the wrapped row pushes ChildC below the list
+		assert.NotContains(t, m.View(), "ChildC")
+		assert.Contains(t, m.View(), "row 1 of 3")
+
+		press(m, key(tea.KeyCtrlDown), key(tea.KeyCtrlDown))
+		assert.Same(t, c, m.workspace.Cursor())
+		assert.Equal(t, 1, m.offset)
+		assert.NotContains(t, m.View(), "ChildA")
+		assert.Contains(t, m.View(), "ChildC")
+
+		// a click on a continuation line selects the item, but does not
+		// hit the bullet
+		_, y := screenPos(t, m, "hotel")
+		m.Update(click(0, y))
+		assert.Same(t, b, m.workspace.Cursor())
+	})
+}
+
+func TestCursorToFirstAndLast(t *testing.T) {
+	m, a, _, c := newTestOutline(t)
+	for i := range 20 {
+		a.Append(m.workspace.NewItem(fmt.Sprintf("Item%02d", i)))
+	}
+	nested := m.workspace.NewItem("Nested")
+	deepest := m.workspace.NewItem("Deepest")
+	folded := m.workspace.NewItem("Folded")
+	c.Append(nested)
+	nested.Append(deepest)
+	deepest.Append(folded)
+	deepest.SetCollapsed(true, false)
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 10})
+
+	// the last row is the deepest displayed tail
+	press(m, key(tea.KeyCtrlEnd))
+	assert.Same(t, deepest, m.workspace.Cursor())
+	assert.Contains(t, m.View(), "Deepest")
+	assert.NotContains(t, m.View(), "ChildA")
+
+	press(m, key(tea.KeyCtrlHome))
+	assert.Same(t, a, m.workspace.Cursor())
+	assert.Equal(t, 0, m.offset)
+	assert.Contains(t, m.View(), "ChildA")
+	assert.NotContains(t, m.View(), "Deepest")
+}
+
+func TestCursorPage(t *testing.T) {
+	t.Run("Paging", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+		for i := range 20 {
+			m.workspace.Root().Append(m.workspace.NewItem(fmt.Sprintf("Item%02d", i)))
+		}
+		m.Update(tea.WindowSizeMsg{Width: 80, Height: 10})
+		rows := m.displayedRows()
+
+		// the list scrolls along with the cursor
+		press(m, key(tea.KeyPgDown))
+		assert.Same(t, rows[6], m.workspace.Cursor())
+		assert.Equal(t, 6, m.offset)
+
+		press(m, key(tea.KeyPgDown), key(tea.KeyPgDown))
+		assert.Same(t, rows[18], m.workspace.Cursor())
+		assert.Equal(t, 17, m.offset)
+
+		// the last page is shorter
+		press(m, key(tea.KeyPgDown))
+		assert.Same(t, rows[22], m.workspace.Cursor())
+		press(m, key(tea.KeyPgDown))
+		assert.Same(t, rows[22], m.workspace.Cursor())
+		assert.Equal(t, 17, m.offset)
+
+		press(m, key(tea.KeyPgUp))
+		assert.Same(t, rows[16], m.workspace.Cursor())
+		assert.Equal(t, 11, m.offset)
+
+		press(m, key(tea.KeyPgUp), key(tea.KeyPgUp), key(tea.KeyPgUp))
+		assert.Same(t, a, m.workspace.Cursor())
+		assert.Equal(t, 0, m.offset)
+	})
+
+	t.Run("NoWindowSize", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+		m.windowHeight = 0
+
+		press(m, key(tea.KeyPgDown))
+		assert.Same(t, a, m.workspace.Cursor())
+	})
+}
+
+func TestUnsavedIndicator(t *testing.T) {
+	m, a, _, _ := newTestOutline(t)
+	require.NoError(t, m.workspace.Save())
+	assert.NotContains(t, m.renderBreadcrumbs(), unsavedIndicator)
+
+	// the title being typed counts before it is stored
+	press(m, runes("!"))
+	assert.Equal(t, "ChildA", a.Title())
+	assert.Contains(t, m.renderBreadcrumbs(), unsavedIndicator+" Root")
+
+	press(m, key(tea.KeyCtrlX), runes("s"))
+	assert.Equal(t, "ChildA!", a.Title())
+	assert.NotContains(t, m.renderBreadcrumbs(), unsavedIndicator)
+
+	a.SetStatus(data.StatusDone)
+	assert.Contains(t, m.renderBreadcrumbs(), unsavedIndicator)
+}
+
+// BenchmarkView renders the outline with the cursor in the middle of
+// a balanced tree, filled level by level with five children per item.
+func BenchmarkView(b *testing.B) {
+	for _, n := range []int{100, 1000, 10000} {
+		w := data.NewWorkspace(b.TempDir(), "Root")
+
+		parents := []*data.Item{w.Root()}
+		for idx := range n {
+			item := w.NewItem(fmt.Sprintf("Item %d", idx))
+			parents[idx/5].Append(item)
+			parents = append(parents, item)
+		}
+
+		rows := w.Root().DisplayedChildren()
+		w.SetCursor(rows[len(rows)/2])
+
+		m, err := NewOutline(w, config.Default())
+		require.NoError(b, err)
+		m.Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+
+		b.Run(fmt.Sprintf("Items%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				m.View()
+			}
+		})
+	}
+}
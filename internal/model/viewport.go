@@ -0,0 +1,184 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+// listHeight returns the number of item rows fitting the window, which
+// leaves room for the breadcrumbs and the status line.
+func (m *Outline) listHeight() int {
+	return max(m.windowHeight-4, 1)
+}
+
+// withSeparators returns the rows with a separator line, represented
+// by nil, inserted before every child of the root but the first row.
+func withSeparators(rows []*data.Item, root *data.Item) []*data.Item {
+	lines := make([]*data.Item, 0, len(rows))
+	for idx, item := range rows {
+		if idx > 0 && item.Parent() == root {
+			lines = append(lines, nil)
+		}
+		lines = append(lines, item)
+	}
+
+	return lines
+}
+
+// displayedLines returns the lines of the item list: the displayed rows
+// and the separators between the top-level items, if enabled.
+func (m *Outline) displayedLines() []*data.Item {
+	rows := m.displayedRows()
+	if !m.showSeparators {
+		return rows
+	}
+
+	return withSeparators(rows, m.workspace.Root())
+}
+
+// lineHeight returns the number of the screen lines taken by the item
+// list line. Only the wrapped titles take more than one.
+func (m *Outline) lineHeight(item *data.Item) int {
+	if item == nil || !m.wrapTitles || item == m.workspace.Cursor() {
+		return 1
+	}
+
+	return len(m.titleLines(item))
+}
+
+// linesHeight returns the number of the screen lines taken by the item
+// list lines.
+func (m *Outline) linesHeight(lines []*data.Item) int {
+	if !m.wrapTitles {
+		return len(lines)
+	}
+
+	height := 0
+	for _, item := range lines {
+		height += m.lineHeight(item)
+	}
+
+	return height
+}
+
+// maxOffset returns the largest scroll offset which keeps the item list
+// filled with lines, if possible.
+func (m *Outline) maxOffset(lines []*data.Item) int {
+	if !m.wrapTitles {
+		return clampOffset(len(lines), len(lines), m.listHeight())
+	}
+
+	offset, height := len(lines), 0
+	for offset > 0 {
+		height += m.lineHeight(lines[offset-1])
+		if height > m.listHeight() {
+			break
+		}
+		offset--
+	}
+
+	return offset
+}
+
+// visibleLines returns the lines fitting the item list from the scroll
+// offset on. The first one is returned even if it does not fit.
+func (m *Outline) visibleLines(lines []*data.Item) []*data.Item {
+	end, height := m.offset, 0
+	for end < len(lines) {
+		height += m.lineHeight(lines[end])
+		if height > m.listHeight() && end > m.offset {
+			break
+		}
+		end++
+	}
+
+	return lines[m.offset:end]
+}
+
+// scrollToCursor adjusts the scroll offset so the cursor line is within
+// the visible part of the item list, and remembers the topmost visible
+// item in the workspace. It returns the displayed lines.
+func (m *Outline) scrollToCursor() []*data.Item {
+	lines := m.displayedLines()
+	height := m.listHeight()
+
+	if idx := slices.Index(lines, m.workspace.Cursor()); idx >= 0 {
+		if idx < m.offset {
+			m.offset = idx
+		} else if !m.wrapTitles && idx >= m.offset+height {
+			m.offset = idx - height + 1
+		} else {
+			for m.offset < idx && m.linesHeight(lines[m.offset:idx+1]) > height {
+				m.offset++
+			}
+		}
+	}
+
+	m.offset = min(max(m.offset, 0), m.maxOffset(lines))
+
+	// the top line might be a separator
+	for _, item := range lines[m.offset:] {
+		if item != nil {
+			m.workspace.SetTop(item)
+			break
+		}
+	}
+
+	return lines
+}
+
+// restoreScroll sets the scroll offset to the line of the topmost
+// visible item stored in the workspace, if it is displayed. The offset
+// is clamped by scrollToCursor, so the list stays filled if the tree
+// has shrunk since the item was stored. Otherwise the cursor line is
+// centered, once the window size is known.
+func (m *Outline) restoreScroll() {
+	lines := m.displayedLines()
+	if idx := slices.Index(lines, m.workspace.Top()); idx >= 0 {
+		m.offset = idx
+		return
+	}
+
+	if m.windowHeight == 0 {
+		m.centerPending = true
+		return
+	}
+	m.centerCursor()
+}
+
+// centerCursor sets the scroll offset so the cursor line is in the
+// middle of the item list, as far as the lines fill it.
+func (m *Outline) centerCursor() {
+	lines := m.displayedLines()
+	if idx := slices.Index(lines, m.workspace.Cursor()); idx >= 0 {
+		m.offset = min(max(idx-m.listHeight()/2, 0), m.maxOffset(lines))
+	}
+}
+
+// scrollIndicator returns the cursor row position, or an empty string
+// if all the lines fit the window.
+func (m *Outline) scrollIndicator() string {
+	if m.linesHeight(m.displayedLines()) <= m.listHeight() {
+		return ""
+	}
+
+	rows := m.displayedRows()
+	idx := slices.Index(rows, m.workspace.Cursor())
+	return fmt.Sprintf("row %d of %d", idx+1, len(rows))
+}
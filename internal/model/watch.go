@@ -0,0 +1,256 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+// fileChangedMsg reports an event on the workspace file. Every mode
+// handles it with fileChanged, which waits for the next one.
+type fileChangedMsg struct{}
+
+// watchWorkspace starts watching the workspace files and returns the
+// command waiting for the first change, or nil if the files can not be
+// watched. The directory is watched rather than the file, since saving
+// replaces the file, and the events of all the documents are reported,
+// since the open one changes.
+func (m *Outline) watchWorkspace() tea.Cmd {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil
+	}
+
+	if err := watcher.Add(m.workspace.Directory()); err != nil {
+		watcher.Close()
+		return nil
+	}
+
+	ext := filepath.Ext(m.workspace.Path())
+
+	// a burst of events is reported once
+	events := make(chan struct{}, 1)
+	go func() {
+		defer close(events)
+
+		for {
+			select {
+			case e, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Ext(e.Name) != ext {
+					continue
+				}
+				select {
+				case events <- struct{}{}:
+				default:
+				}
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+
+	m.watcher = watcher
+	m.fileEvents = events
+	return m.waitForFileChange()
+}
+
+// Close stops watching the workspace files. It is called once the
+// program exits.
+func (m *Outline) Close() error {
+	if m.watcher == nil {
+		return nil
+	}
+
+	err := m.watcher.Close()
+	m.watcher = nil
+	return err
+}
+
+func (m *Outline) waitForFileChange() tea.Cmd {
+	events := m.fileEvents
+	if events == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		// the events are closed with the watcher
+		if _, ok := <-events; !ok {
+			return nil
+		}
+		return fileChangedMsg{}
+	}
+}
+
+// fileChanged asks whether to reload the workspace file if it was
+// changed by someone else, leaving the current mode. The own saves are
+// ignored. The note and prompt modes are not left, so the text being
+// typed is not lost: the question is asked once they are done, see
+// finishEdit.
+func (m *Outline) fileChanged(current tea.Model) (tea.Model, tea.Cmd) {
+	if !m.workspace.ChangedOnDisk() {
+		return current, m.waitForFileChange()
+	}
+
+	if editing(current) {
+		m.fileChangePending = true
+		return current, m.waitForFileChange()
+	}
+
+	return m.promptFileChanged(), m.waitForFileChange()
+}
+
+// editing reports whether the mode edits a text, which leaving the mode
+// would throw away.
+func editing(mode tea.Model) bool {
+	switch mode.(type) {
+	case noteMode, promptMode:
+		return true
+	}
+
+	return false
+}
+
+// finishEdit returns the mode following an edit, or asks whether to
+// reload the workspace file if it changed during the edit.
+func (m *Outline) finishEdit(next tea.Model, cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	if !m.fileChangePending || editing(next) {
+		return next, cmd
+	}
+
+	m.fileChangePending = false
+	if !m.workspace.ChangedOnDisk() {
+		return next, cmd
+	}
+
+	return m.promptFileChanged(), cmd
+}
+
+// promptFileChanged asks whether to reload the workspace file changed
+// by someone else.
+func (m *Outline) promptFileChanged() tea.Model {
+	m.statusLine = m.keys.fileChanged.menu()
+	return fileChangedMode{m}
+}
+
+// reload replaces the workspace with the one loaded from the file and
+// resets the view to its saved state.
+func (m *Outline) reload() (tea.Model, tea.Cmd) {
+	w, err := m.workspace.Reload()
+	if err != nil {
+		m.statusLine = m.renderStatusError(err.Error())
+		return m, nil
+	}
+
+	m.setWorkspace(w)
+
+	m.statusLine = m.renderStatusMessage("Reloaded")
+	return m, nil
+}
+
+// setWorkspace replaces the workspace and resets the view to its saved
+// state.
+func (m *Outline) setWorkspace(w *data.Workspace) {
+	m.workspace = w
+	m.clearSelection()
+	m.lastAction = nil
+	m.search = search{}
+	m.folds = newFoldMemory(foldMemorySize)
+	m.capture = nil
+
+	m.updateTextInput(w.Cursor())
+	m.textInput.CursorEnd()
+	m.offset = 0
+	m.restoreScroll()
+}
+
+// confirmReload reloads the workspace file, unless there are unsaved
+// changes, which have to be confirmed first.
+func (m *Outline) confirmReload() (tea.Model, tea.Cmd) {
+	if !m.unsaved() {
+		return m.reload()
+	}
+
+	m.statusLine = m.keys.reloadConfirm.menu() + "  [esc] cancel"
+	return reloadConfirmMode{m}, nil
+}
+
+// reloadConfirmMode asks whether to discard the unsaved changes on
+// reload.
+type reloadConfirmMode struct {
+	*Outline
+}
+
+func (m reloadConfirmMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m, m.waitForFileChange()
+	case tea.WindowSizeMsg:
+		m.updateWindowSize(msg)
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyEsc {
+			m.Outline.statusLine = ""
+			return m.Outline, nil
+		}
+
+		if m.keys.reloadConfirm.match(msg) == "reloadAnyway" {
+			return m.reload()
+		}
+	}
+
+	return m, nil
+}
+
+// fileChangedMode asks what to do with the workspace file changed by
+// someone else.
+type fileChangedMode struct {
+	*Outline
+}
+
+func (m fileChangedMode) Update(message tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := message.(type) {
+	case autosaveMsg:
+		return m, m.autosave()
+	case fileChangedMsg:
+		return m, m.waitForFileChange()
+	case tea.WindowSizeMsg:
+		m.updateWindowSize(msg)
+	case tea.KeyMsg:
+		switch m.keys.fileChanged.match(msg) {
+		case "reloadChanged":
+			return m.reload()
+		case "ignoreChanged":
+			// the next save overwrites the file
+			m.workspace.IgnoreDiskChanges()
+			m.Outline.statusLine = ""
+			return m.Outline, nil
+		default:
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
@@ -0,0 +1,220 @@
+// Copyright 2025 Sergey Vinogradov
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/boogie-byte/oli/internal/data"
+)
+
+// changeOnDisk saves the workspace file with the first top-level item
+// renamed, as another instance of the app would.
+func changeOnDisk(t *testing.T, w *data.Workspace, title string) {
+	t.Helper()
+
+	other, err := data.LoadWorkspace(w.Directory(), nil)
+	require.NoError(t, err)
+	other.Root().Head().SetTitle(title)
+	require.NoError(t, other.Save())
+
+	// the modification time might not change within its precision
+	require.NoError(t, os.Chtimes(w.Path(), time.Time{}, time.Now().Add(time.Minute)))
+}
+
+func TestFileChanged(t *testing.T) {
+	t.Run("OwnSave", func(t *testing.T) {
+		m, _, _, _ := newTestOutline(t)
+		require.NoError(t, m.workspace.Save())
+
+		model, _ := m.Update(fileChangedMsg{})
+		assert.Same(t, m, model)
+		assert.Empty(t, m.statusLine)
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+		require.NoError(t, m.workspace.Save())
+		m.selection[a] = struct{}{}
+		changeOnDisk(t, m.workspace, "Changed elsewhere")
+
+		// the prompt interrupts any mode
+		model := press(m, key(tea.KeyCtrlC))
+		model, _ = model.Update(fileChangedMsg{})
+		require.IsType(t, fileChangedMode{}, model)
+		assert.Contains(t, m.statusLine, "file changed on disk: [r]eload  [i]gnore")
+
+		model = press(model, runes("r"))
+		assert.Same(t, m, model)
+		assert.Equal(t, "Changed elsewhere", m.workspace.Cursor().Title())
+		assert.Equal(t, "Changed elsewhere", m.textInput.Value())
+		assert.Empty(t, m.selection)
+		assert.False(t, m.workspace.ChangedOnDisk())
+	})
+
+	t.Run("Ignore", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+		require.NoError(t, m.workspace.Save())
+		changeOnDisk(t, m.workspace, "Changed elsewhere")
+
+		model, _ := m.Update(fileChangedMsg{})
+		model = press(model, runes("i"))
+		assert.Same(t, m, model)
+		assert.Equal(t, "ChildA", a.Title())
+		assert.Empty(t, m.statusLine)
+
+		// the same change is not reported again
+		model, _ = m.Update(fileChangedMsg{})
+		assert.Same(t, m, model)
+	})
+
+	t.Run("Note", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+		require.NoError(t, m.workspace.Save())
+		changeOnDisk(t, m.workspace, "Changed elsewhere")
+
+		// the note being typed is kept, and the prompt waits for it
+		model := press(m, key(tea.KeyCtrlC), runes("n"), runes("Typed"))
+		model, _ = model.Update(fileChangedMsg{})
+		require.IsType(t, noteMode{}, model)
+
+		model = press(model, runes(" more"), key(tea.KeyEsc))
+		require.IsType(t, fileChangedMode{}, model)
+		assert.Equal(t, "Typed more", a.Note())
+	})
+
+	t.Run("Prompt", func(t *testing.T) {
+		m, _, _, _ := newTestOutline(t)
+		require.NoError(t, m.workspace.Save())
+
+		model, _ := m.prompt("Value: ", func(m *Outline, value string) (tea.Model, tea.Cmd) {
+			return m, nil
+		})
+		changeOnDisk(t, m.workspace, "Changed elsewhere")
+		model, _ = model.Update(fileChangedMsg{})
+		require.IsType(t, promptMode{}, model)
+
+		model = press(model, key(tea.KeyEsc))
+		require.IsType(t, fileChangedMode{}, model)
+
+		// the change is asked about once
+		model = press(model, runes("i"))
+		assert.Same(t, m, model)
+		assert.False(t, m.fileChangePending)
+	})
+}
+
+func TestWatchWorkspace(t *testing.T) {
+	m, _, _, _ := newTestOutline(t)
+	require.NoError(t, m.workspace.Save())
+
+	cmd := m.watchWorkspace()
+	require.NotNil(t, cmd)
+	t.Cleanup(func() { assert.NoError(t, m.Close()) })
+
+	msgs := make(chan tea.Msg)
+	go func() { msgs <- cmd() }()
+
+	changeOnDisk(t, m.workspace, "Changed elsewhere")
+
+	select {
+	case msg := <-msgs:
+		assert.Equal(t, fileChangedMsg{}, msg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event on the workspace file change")
+	}
+}
+
+func TestCloseWatcher(t *testing.T) {
+	m, _, _, _ := newTestOutline(t)
+	require.NoError(t, m.workspace.Save())
+
+	cmd := m.watchWorkspace()
+	require.NotNil(t, cmd)
+	require.NoError(t, m.Close())
+
+	// the waiting command returns once the watcher is closed
+	msgs := make(chan tea.Msg)
+	go func() { msgs <- cmd() }()
+
+	select {
+	case msg := <-msgs:
+		assert.Nil(t, msg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no return after the watcher is closed")
+	}
+	assert.NoError(t, m.Close())
+}
+
+func TestSaveConflict(t *testing.T) {
+	m, a, _, _ := newTestOutline(t)
+	require.NoError(t, m.workspace.Save())
+	changeOnDisk(t, m.workspace, "Changed elsewhere")
+	a.SetTitle("Changed here")
+
+	model := press(m, key(tea.KeyCtrlX), runes("s"))
+	require.IsType(t, fileChangedMode{}, model)
+	assert.True(t, m.workspace.Dirty())
+
+	// ignoring the change lets the save overwrite it
+	press(model, runes("i"))
+	press(m, key(tea.KeyCtrlX), runes("s"))
+	assert.Contains(t, m.statusLine, "Saved!")
+	assert.False(t, m.workspace.Dirty())
+}
+
+func TestReload(t *testing.T) {
+	t.Run("Saved", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+		require.NoError(t, m.workspace.Save())
+		changeOnDisk(t, m.workspace, "Changed elsewhere")
+
+		model := press(m, key(tea.KeyCtrlX), runes("R"))
+		assert.Same(t, m, model)
+		assert.Contains(t, m.statusLine, "Reloaded")
+
+		cur := m.workspace.Cursor()
+		assert.Equal(t, "Changed elsewhere", cur.Title())
+		assert.NotSame(t, a, cur)
+		got, ok := m.workspace.GetByID(a.ID())
+		assert.True(t, ok)
+		assert.Same(t, cur, got)
+	})
+
+	t.Run("Unsaved", func(t *testing.T) {
+		m, a, _, _ := newTestOutline(t)
+		require.NoError(t, m.workspace.Save())
+		press(m, runes("!"))
+
+		model := press(m, key(tea.KeyCtrlX), runes("R"))
+		require.IsType(t, reloadConfirmMode{}, model)
+		assert.Contains(t, m.statusLine, "[R] reload anyway  [esc] cancel")
+
+		model = press(model, key(tea.KeyEsc))
+		assert.Same(t, m, model)
+		assert.Equal(t, "ChildA!", m.textInput.Value())
+
+		press(m, key(tea.KeyCtrlX), runes("R"), runes("R"))
+		assert.Equal(t, "ChildA", m.textInput.Value())
+		assert.Equal(t, a.ID(), m.workspace.Cursor().ID())
+		assert.False(t, m.unsaved())
+	})
+}
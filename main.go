@@ -15,33 +15,152 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
 	"log"
 	"os"
+	"path/filepath"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
 
+	"github.com/boogie-byte/oli/internal/config"
 	"github.com/boogie-byte/oli/internal/data"
 	"github.com/boogie-byte/oli/internal/model"
 )
 
 func main() {
-	directory := os.ExpandEnv("$HOME/.oli")
-	if err := os.MkdirAll(directory, 0700); err != nil {
+	dir := flag.String("dir", os.ExpandEnv("$HOME/.oli"), "workspace directory")
+	doc := flag.String("doc", data.DefaultDocument, "document in the workspace directory")
+	flag.Parse()
+
+	directory, err := workspaceDirectory(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cfg, err := config.Load(directory)
+	if err != nil {
 		log.Fatal(err)
 	}
 
-	w, err := data.LoadWorkspace(directory)
+	if flag.NArg() > 0 {
+		if err := runCommand(directory, *doc, cfg, flag.Args()); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	w, err := loadWorkspace(directory, *doc, cfg)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	m, err := model.NewOutline(w)
+	m, err := model.NewOutline(w, cfg)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	_, err = p.Run()
+	m.Close()
+	if err != nil {
 		log.Fatal(err)
 	}
 }
+
+// workspaceDirectory validates the workspace directory path, creating the
+// directory when it is missing, and returns its absolute path.
+func workspaceDirectory(path string) (string, error) {
+	if path == "" {
+		return "", errors.New("the workspace directory path is empty")
+	}
+
+	directory, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := os.Stat(directory)
+	if errors.Is(err, fs.ErrNotExist) {
+		return directory, os.MkdirAll(directory, 0700)
+	} else if err != nil {
+		return "", err
+	}
+
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", directory)
+	}
+
+	return directory, nil
+}
+
+const passphraseEnv = "OLI_PASSPHRASE"
+
+// passphraseReader returns a function reading the workspace passphrase
+// from the environment, or from the terminal if it is not set there.
+// The passphrase is asked for once.
+func passphraseReader() data.PassphraseFunc {
+	var passphrase string
+
+	return func() (string, error) {
+		if passphrase != "" {
+			return passphrase, nil
+		}
+
+		if p := os.Getenv(passphraseEnv); p != "" {
+			passphrase = p
+			return passphrase, nil
+		}
+
+		if !term.IsTerminal(os.Stdin.Fd()) {
+			return "", fmt.Errorf("no passphrase, set %s", passphraseEnv)
+		}
+
+		fmt.Fprint(os.Stderr, "Passphrase: ")
+		p, err := term.ReadPassword(os.Stdin.Fd())
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		if len(p) == 0 {
+			return "", fmt.Errorf("empty passphrase")
+		}
+
+		passphrase = string(p)
+		return passphrase, nil
+	}
+}
+
+// loadWorkspace loads the workspace document and applies the config
+// settings to it.
+func loadWorkspace(directory, document string, cfg *config.Config) (*data.Workspace, error) {
+	passphrase := passphraseReader()
+
+	w, err := data.LoadDocument(directory, document, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	// the encrypted files stay encrypted, the plain ones are encrypted
+	// from the next save on
+	if cfg.Encrypt && !w.Encrypted() {
+		p, err := passphrase()
+		if err != nil {
+			return nil, err
+		}
+		w.SetPassphrase(p)
+	}
+
+	w.SetBackups(cfg.Backups)
+	w.SetBackupLimit(cfg.BackupLimit)
+	w.SetCompress(cfg.Compress)
+	w.SetAutoComplete(cfg.AutoCompleteParents)
+	if err := w.SetStatusAliases(cfg.StatusAliases); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}